@@ -0,0 +1,763 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const (
+	injectPath     = "github.com/Drafteame/inject"
+	containerPath  = injectPath + "/container"
+	dependencyPath = injectPath + "/dependency"
+)
+
+// registration is a dependency registered on the package, with the expressions of its factory and arguments.
+type registration struct {
+	name    string
+	factory ast.Expr
+	args    []ast.Expr
+	field   string
+	rtype   types.Type
+}
+
+// generator finds the registrations of a package and writes the code that builds them.
+type generator struct {
+	pkg     *packages.Package
+	output  string
+	regs    []*registration
+	byName  map[string]*registration
+	fields  map[string]bool
+	imports map[string]string
+	aliases map[string]bool
+	locals  int
+	errors  bool
+}
+
+// generate loads the package of the pattern and returns the path of the file to generate, and its content. The path
+// is the output name relative to the package directory.
+func generate(pattern, output string) (string, []byte, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
+	}
+
+	pkgs, err := packages.Load(cfg, pattern)
+	if err != nil {
+		return "", nil, fmt.Errorf("inject: can't load package `%s`: %w", pattern, err)
+	}
+
+	if len(pkgs) != 1 || len(pkgs[0].GoFiles) == 0 {
+		return "", nil, fmt.Errorf("inject: pattern `%s` should match a single package", pattern)
+	}
+
+	pkg := pkgs[0]
+
+	if !filepath.IsAbs(output) {
+		output = filepath.Join(filepath.Dir(pkg.GoFiles[0]), output)
+	}
+
+	// The generated file of a previous run may not compile after the registrations change, so its errors are ignored.
+	for _, e := range pkg.Errors {
+		if !strings.HasPrefix(e.Pos, output) {
+			return "", nil, fmt.Errorf("inject: can't load package `%s`: %v", pattern, e)
+		}
+	}
+
+	g := &generator{
+		pkg:     pkg,
+		output:  output,
+		byName:  make(map[string]*registration),
+		fields:  make(map[string]bool),
+		imports: map[string]string{"context": "context", "fmt": "fmt"},
+		aliases: map[string]bool{"context": true, "fmt": true},
+	}
+
+	if err := g.collect(); err != nil {
+		return "", nil, err
+	}
+
+	src, err := g.render()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return output, src, nil
+}
+
+// collect finds every registration of the package, skipping the output file.
+func (g *generator) collect() error {
+	for _, file := range g.pkg.Syntax {
+		if g.pkg.Fset.Position(file.Pos()).Filename == g.output {
+			continue
+		}
+
+		var err error
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || err != nil {
+				return err == nil
+			}
+
+			var reg *registration
+
+			reg, err = g.registration(call)
+			if err != nil || reg == nil {
+				return err == nil
+			}
+
+			if _, ok := g.byName[reg.name]; ok {
+				err = g.errorf(call, "dependency `%s` is registered more than once", reg.name)
+				return false
+			}
+
+			g.regs = append(g.regs, reg)
+			g.byName[reg.name] = reg
+
+			return false
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(g.regs) == 0 {
+		return fmt.Errorf("inject: no dependencies registered on package `%s`", g.pkg.PkgPath)
+	}
+
+	return nil
+}
+
+// registration returns the dependency registered by the call, or nil if the call doesn't register one. Calls to
+// `inject.Provide`, `inject.Singleton` and the `Provide` method of a container are registrations, and they can receive
+// either the factory and its arguments, or a `dependency.New` or `dependency.NewSingleton` call.
+func (g *generator) registration(call *ast.CallExpr) (*registration, error) {
+	fn := g.callee(call)
+	if fn == nil || fn.Pkg() == nil || len(call.Args) < 2 {
+		return nil, nil
+	}
+
+	sig := fn.Type().(*types.Signature)
+	path := fn.Pkg().Path()
+
+	switch {
+	case path == injectPath && sig.Recv() == nil && (fn.Name() == "Provide" || fn.Name() == "Singleton"):
+	case (path == injectPath || path == containerPath) && sig.Recv() != nil && fn.Name() == "Provide":
+	default:
+		return nil, nil
+	}
+
+	name, ok := g.constString(call.Args[0])
+	if !ok {
+		return nil, g.errorf(call, "name of the dependency should be a constant")
+	}
+
+	factory, args := call.Args[1], g.withoutOptions(call.Args[2:])
+
+	if g.isDependency(factory) {
+		if !g.isDependencyNew(factory) || len(factory.(*ast.CallExpr).Args) == 0 {
+			return nil, g.errorf(factory, "dependency `%s` should be built with a `dependency.New` call to be generated", name)
+		}
+
+		dep := factory.(*ast.CallExpr)
+		factory, args = dep.Args[0], dep.Args[1:]
+	}
+
+	ftype, ok := g.pkg.TypesInfo.TypeOf(factory).(*types.Signature)
+	if !ok || ftype.Results().Len() == 0 {
+		return nil, g.errorf(factory, "factory of dependency `%s` should be a function that returns at least one value", name)
+	}
+
+	return &registration{
+		name:    name,
+		factory: factory,
+		args:    args,
+		field:   g.fieldName(name),
+		rtype:   ftype.Results().At(0).Type(),
+	}, nil
+}
+
+// callee returns the function or method called, or nil if it is not a declared one.
+func (g *generator) callee(call *ast.CallExpr) *types.Func {
+	fun := call.Fun
+
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		fun = f.X
+	case *ast.IndexListExpr:
+		fun = f.X
+	}
+
+	var ident *ast.Ident
+
+	switch f := fun.(type) {
+	case *ast.Ident:
+		ident = f
+	case *ast.SelectorExpr:
+		ident = f.Sel
+	default:
+		return nil
+	}
+
+	fn, _ := g.pkg.TypesInfo.Uses[ident].(*types.Func)
+
+	return fn
+}
+
+// isCallTo returns true if the expression is a call to one of the functions of the package path.
+func (g *generator) isCallTo(e ast.Expr, path string, names ...string) bool {
+	call, ok := e.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+
+	fn := g.callee(call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != path {
+		return false
+	}
+
+	for _, name := range names {
+		if fn.Name() == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isDependency returns true if the expression is a `dependency.Dependency` value.
+func (g *generator) isDependency(e ast.Expr) bool {
+	named, ok := types.Unalias(g.pkg.TypesInfo.TypeOf(e)).(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == dependencyPath && named.Obj().Name() == "Dependency"
+}
+
+func (g *generator) isDependencyNew(e ast.Expr) bool {
+	return g.isCallTo(e, dependencyPath, "New", "NewSingleton")
+}
+
+// reference returns the name of the dependency injected by the expression, if it is a call to `dependency.Inject` or
+// `inject.Dep`.
+func (g *generator) reference(e ast.Expr) (string, bool, error) {
+	if !g.isCallTo(e, dependencyPath, "Inject") && !g.isCallTo(e, injectPath, "Dep") {
+		return "", false, nil
+	}
+
+	name, ok := g.constString(e.(*ast.CallExpr).Args[0])
+	if !ok {
+		return "", true, g.errorf(e, "name of the injected dependency should be a constant")
+	}
+
+	return name, true, nil
+}
+
+// withoutOptions removes the provide options from the arguments of a registration. Every dependency is built once on
+// the generated graph, so they don't change the generated code.
+func (g *generator) withoutOptions(args []ast.Expr) []ast.Expr {
+	res := make([]ast.Expr, 0, len(args))
+
+	for _, arg := range args {
+		named, ok := types.Unalias(g.pkg.TypesInfo.TypeOf(arg)).(*types.Named)
+		if ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == containerPath && named.Obj().Name() == "ProvideOption" {
+			continue
+		}
+
+		res = append(res, arg)
+	}
+
+	return res
+}
+
+// constString returns the value of a constant string expression.
+func (g *generator) constString(e ast.Expr) (string, bool) {
+	tv, ok := g.pkg.TypesInfo.Types[e]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+
+	return constant.StringVal(tv.Value), true
+}
+
+// sorted returns the registrations in dependency order, so every dependency is built after the ones it injects.
+func (g *generator) sorted() ([]*registration, error) {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+
+	state := make(map[*registration]int)
+	res := make([]*registration, 0, len(g.regs))
+
+	var visit func(reg *registration, path []string) error
+
+	visit = func(reg *registration, path []string) error {
+		switch state[reg] {
+		case visited:
+			return nil
+		case visiting:
+			return g.errorf(reg.factory, "dependency cycle: %s", strings.Join(append(path, reg.name), " -> "))
+		}
+
+		state[reg] = visiting
+
+		refs, err := g.references(reg.args)
+		if err != nil {
+			return err
+		}
+
+		for _, ref := range refs {
+			dep, ok := g.byName[ref.name]
+			if !ok {
+				return g.errorf(ref.expr, "dependency `%s` is not registered on package `%s`", ref.name, g.pkg.PkgPath)
+			}
+
+			if _, ok := g.lazyValue(dep, ref.param); ok {
+				continue
+			}
+
+			if err := visit(dep, append(path, reg.name)); err != nil {
+				return err
+			}
+		}
+
+		state[reg] = visited
+		res = append(res, reg)
+
+		return nil
+	}
+
+	for _, reg := range g.regs {
+		if err := visit(reg, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// injected is a reference to a dependency found on the arguments of a registration.
+type injected struct {
+	name  string
+	expr  ast.Expr
+	param types.Type
+}
+
+// references returns every dependency injected on the arguments, including the ones of nested dependencies.
+func (g *generator) references(args []ast.Expr) ([]injected, error) {
+	refs := make([]injected, 0)
+
+	for _, arg := range args {
+		name, ok, err := g.reference(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			refs = append(refs, injected{name: name, expr: arg, param: g.paramOf(arg)})
+			continue
+		}
+
+		if g.isDependencyNew(arg) {
+			nested, err := g.references(arg.(*ast.CallExpr).Args[1:])
+			if err != nil {
+				return nil, err
+			}
+
+			refs = append(refs, nested...)
+		}
+	}
+
+	return refs, nil
+}
+
+// render writes the generated file.
+func (g *generator) render() ([]byte, error) {
+	regs, err := g.sorted()
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+
+	for _, reg := range regs {
+		if err := g.call(&body, reg.name, "g."+reg.field, reg.factory, reg.args); err != nil {
+			return nil, err
+		}
+	}
+
+	// The field types are printed before the imports, so the packages they use are imported.
+	var fields bytes.Buffer
+
+	for _, reg := range g.regs {
+		fmt.Fprintf(&fields, "// %s is the dependency `%s`.\n%s %s\n", reg.field, reg.name, reg.field, g.typeString(reg.rtype))
+	}
+
+	var out bytes.Buffer
+
+	fmt.Fprintf(&out, "// Code generated by inject gen. DO NOT EDIT.\n\npackage %s\n\n", g.pkg.Name)
+	fmt.Fprintf(&out, "import (\n")
+
+	paths := make([]string, 0, len(g.imports))
+
+	for path := range g.imports {
+		if path == "fmt" && !g.errors {
+			continue
+		}
+
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if name := g.imports[path]; name != filepath.Base(path) {
+			fmt.Fprintf(&out, "%s ", name)
+		}
+
+		fmt.Fprintf(&out, "%q\n", path)
+	}
+
+	fmt.Fprintf(&out, ")\n\n")
+	fmt.Fprintf(&out, "// InjectGraph holds an instance of every dependency registered on the package.\n")
+	fmt.Fprintf(&out, "type InjectGraph struct {\n")
+
+	out.Write(fields.Bytes())
+	fmt.Fprintf(&out, "}\n\n")
+	fmt.Fprintf(&out, "// BuildInjectGraph builds every dependency registered on the package with no reflection, after the ones they\n")
+	fmt.Fprintf(&out, "// inject. Each dependency is built once, and shared by every dependency that injects it.\n")
+	fmt.Fprintf(&out, "func BuildInjectGraph(ctx context.Context) (*InjectGraph, error) {\n")
+	fmt.Fprintf(&out, "g := &InjectGraph{}\n\n")
+
+	if g.errors {
+		fmt.Fprintf(&out, "var err error\n\n")
+	}
+
+	out.Write(body.Bytes())
+
+	fmt.Fprintf(&out, "return g, nil\n}\n")
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("inject: [internal-error] generated code is not valid: %w", err)
+	}
+
+	return src, nil
+}
+
+// call writes the statements that build a dependency by calling its factory, and store its first result on the target.
+// Nested dependencies on the arguments are built first, on local variables.
+func (g *generator) call(w *bytes.Buffer, name, target string, factory ast.Expr, args []ast.Expr) error {
+	ftype := g.pkg.TypesInfo.TypeOf(factory).(*types.Signature)
+	params := ftype.Params()
+	values := make([]string, 0, params.Len())
+
+	offset := 0
+
+	if params.Len() == len(args)+1 && g.isContext(params.At(0).Type()) {
+		values = append(values, "ctx")
+		offset = 1
+	}
+
+	if !ftype.Variadic() && params.Len() != len(args)+offset {
+		return g.errorf(factory, "factory of dependency `%s` receives %d arguments, got %d", name, params.Len(), len(args))
+	}
+
+	for i, arg := range args {
+		value, err := g.argument(w, name, arg, g.paramType(ftype, i+offset))
+		if err != nil {
+			return err
+		}
+
+		values = append(values, value)
+	}
+
+	fsrc, err := g.source(factory)
+	if err != nil {
+		return err
+	}
+
+	results := ftype.Results()
+	lhs := make([]string, results.Len())
+
+	for i := range lhs {
+		lhs[i] = "_"
+	}
+
+	lhs[0] = target
+	invoke := fmt.Sprintf("(%s)(%s)", fsrc, strings.Join(values, ", "))
+
+	if _, ok := factory.(*ast.FuncLit); !ok {
+		invoke = fmt.Sprintf("%s(%s)", fsrc, strings.Join(values, ", "))
+	}
+
+	if !g.isError(results.At(results.Len() - 1).Type()) {
+		fmt.Fprintf(w, "%s = %s\n\n", strings.Join(lhs, ", "), invoke)
+		return nil
+	}
+
+	if results.Len() == 1 {
+		return g.errorf(factory, "factory of dependency `%s` should return a value besides the error", name)
+	}
+
+	g.errors = true
+	lhs[len(lhs)-1] = "err"
+
+	fmt.Fprintf(w, "if %s = %s; err != nil {\n", strings.Join(lhs, ", "), invoke)
+	fmt.Fprintf(w, "return nil, fmt.Errorf(\"inject: error constructing `%%s`: %%w\", %q, err)\n}\n\n", name)
+
+	return nil
+}
+
+// argument returns the expression of an argument passed to a parameter of the given type. Nested dependencies, and
+// functions passed to non function parameters, are built first on a local variable.
+func (g *generator) argument(w *bytes.Buffer, owner string, arg ast.Expr, param types.Type) (string, error) {
+	name, ok, err := g.reference(arg)
+	if err != nil {
+		return "", err
+	}
+
+	if ok {
+		dep := g.byName[name]
+
+		if vtype, ok := g.lazyValue(dep, param); ok {
+			return fmt.Sprintf("func() (%s, error) { return g.%s, nil }", g.typeString(vtype), dep.field), nil
+		}
+
+		return "g." + dep.field, nil
+	}
+
+	atype := g.pkg.TypesInfo.TypeOf(arg)
+
+	if named, ok := types.Unalias(atype).(*types.Named); ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == dependencyPath {
+		if !g.isDependencyNew(arg) {
+			return "", g.errorf(arg, "argument of type `%s` of dependency `%s` can't be generated", named.Obj().Name(), owner)
+		}
+
+		nested := arg.(*ast.CallExpr)
+
+		return g.local(w, owner, nested.Args[0], nested.Args[1:])
+	}
+
+	if _, ok := atype.Underlying().(*types.Signature); ok {
+		if _, ok := param.Underlying().(*types.Signature); !ok {
+			return g.local(w, owner, arg, nil)
+		}
+	}
+
+	return g.source(arg)
+}
+
+// local builds a nested dependency on a new local variable, and returns its name.
+func (g *generator) local(w *bytes.Buffer, owner string, factory ast.Expr, args []ast.Expr) (string, error) {
+	ftype, ok := g.pkg.TypesInfo.TypeOf(factory).(*types.Signature)
+	if !ok || ftype.Results().Len() == 0 {
+		return "", g.errorf(factory, "nested factory of dependency `%s` should be a function that returns at least one value", owner)
+	}
+
+	g.locals++
+	name := fmt.Sprintf("v%d", g.locals)
+
+	fmt.Fprintf(w, "var %s %s\n\n", name, g.typeString(ftype.Results().At(0).Type()))
+
+	if err := g.call(w, owner, name, factory, args); err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// source returns the source of an expression that is copied to the generated file. Package names are replaced by the
+// names they are imported with, and local identifiers can't be used, since they are not available on the generated
+// function.
+func (g *generator) source(e ast.Expr) (string, error) {
+	var err error
+
+	ast.Inspect(e, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok || err != nil {
+			return err == nil
+		}
+
+		switch obj := g.pkg.TypesInfo.Uses[ident].(type) {
+		case nil:
+		case *types.PkgName:
+			ident.Name = g.alias(obj.Imported())
+		default:
+			scope := obj.Parent()
+			inside := obj.Pos() >= e.Pos() && obj.Pos() < e.End()
+
+			if obj.Pkg() == g.pkg.Types && scope != nil && scope != g.pkg.Types.Scope() && !inside {
+				err = g.errorf(ident, "local identifier `%s` can't be used on generated code", ident.Name)
+			}
+		}
+
+		return true
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+
+	if err := format.Node(&buf, g.pkg.Fset, e); err != nil {
+		return "", fmt.Errorf("inject: [internal-error] can't print expression: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// alias returns the name the package is imported with on the generated file, adding the import if it is new.
+func (g *generator) alias(pkg *types.Package) string {
+	if name, ok := g.imports[pkg.Path()]; ok {
+		return name
+	}
+
+	name := pkg.Name()
+
+	for i := 2; g.aliases[name]; i++ {
+		name = fmt.Sprintf("%s%d", pkg.Name(), i)
+	}
+
+	g.imports[pkg.Path()] = name
+	g.aliases[name] = true
+
+	return name
+}
+
+// typeString returns the source of the type on the generated file.
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, func(pkg *types.Package) string {
+		if pkg == g.pkg.Types {
+			return ""
+		}
+
+		return g.alias(pkg)
+	})
+}
+
+// paramType returns the type of the parameter on the given index, that is the element type of the variadic parameter
+// for the indexes past it.
+func (g *generator) paramType(sig *types.Signature, index int) types.Type {
+	params := sig.Params()
+
+	if sig.Variadic() && index >= params.Len()-1 {
+		return params.At(params.Len() - 1).Type().(*types.Slice).Elem()
+	}
+
+	return params.At(index).Type()
+}
+
+// paramOf returns the type of the parameter the argument is passed to, or nil if it can't be found.
+func (g *generator) paramOf(arg ast.Expr) types.Type {
+	for _, reg := range g.regs {
+		if t := g.findParam(reg.factory, reg.args, arg); t != nil {
+			return t
+		}
+	}
+
+	return nil
+}
+
+func (g *generator) findParam(factory ast.Expr, args []ast.Expr, arg ast.Expr) types.Type {
+	ftype, ok := g.pkg.TypesInfo.TypeOf(factory).(*types.Signature)
+	if !ok {
+		return nil
+	}
+
+	offset := 0
+
+	if ftype.Params().Len() == len(args)+1 && g.isContext(ftype.Params().At(0).Type()) {
+		offset = 1
+	}
+
+	for i, a := range args {
+		if a == arg && i+offset < ftype.Params().Len() {
+			return g.paramType(ftype, i+offset)
+		}
+
+		if g.isDependencyNew(a) {
+			nested := a.(*ast.CallExpr)
+
+			if t := g.findParam(nested.Args[0], nested.Args[1:], arg); t != nil {
+				return t
+			}
+		}
+	}
+
+	return nil
+}
+
+// isLazy returns true if the parameter type is a lazy resolver, like `func() (T, error)`.
+func (g *generator) isLazy(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+
+	sig, ok := t.Underlying().(*types.Signature)
+
+	return ok && sig.Params().Len() == 0 && sig.Results().Len() == 2 && g.isError(sig.Results().At(1).Type())
+}
+
+// lazyValue returns the value type of the lazy parameter that receives the dependency, and false if the dependency is
+// passed as it is, since the parameter is not lazy or the dependency is already a function of its type.
+func (g *generator) lazyValue(dep *registration, param types.Type) (types.Type, bool) {
+	if !g.isLazy(param) || types.AssignableTo(dep.rtype, param) {
+		return nil, false
+	}
+
+	return param.Underlying().(*types.Signature).Results().At(0).Type(), true
+}
+
+func (g *generator) isContext(t types.Type) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "context" && named.Obj().Name() == "Context"
+}
+
+func (g *generator) isError(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+// fieldName returns a unique exported field name for the dependency name, like `UsersService` for `users.service`.
+func (g *generator) fieldName(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+
+	for _, part := range parts {
+		runes := []rune(part)
+		b.WriteString(string(unicode.ToUpper(runes[0])) + string(runes[1:]))
+	}
+
+	field := b.String()
+
+	if field == "" || !unicode.IsLetter([]rune(field)[0]) {
+		field = "Dep" + field
+	}
+
+	unique := field
+
+	for i := 2; g.fields[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", field, i)
+	}
+
+	g.fields[unique] = true
+
+	return unique
+}
+
+// errorf returns an error that points at the position of the node.
+func (g *generator) errorf(n ast.Node, format string, args ...any) error {
+	return fmt.Errorf("inject: %s: %s", g.pkg.Fset.Position(n.Pos()), fmt.Sprintf(format, args...))
+}
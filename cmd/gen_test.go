@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	// The packages of testdata import the library. The generator itself doesn't, so the module doesn't require it, and
+	// the go.work file resolves it to the parent directory, so testdata uses the library as it is on the repository.
+	_ "github.com/Drafteame/inject"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("generate static wiring", func(t *testing.T) {
+		path, src, err := generate("./testdata/app", "inject_gen.go")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		expected, err := os.ReadFile("testdata/app/inject_gen.go")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		assert.Equal(t, "inject_gen.go", filepath.Base(path))
+		assert.Equal(t, string(expected), string(src))
+	})
+
+	t.Run("dependency cycle", func(t *testing.T) {
+		_, _, err := generate("./testdata/cycle", "inject_gen.go")
+
+		assert.ErrorContains(t, err, "dependency cycle: a -> b -> a")
+	})
+
+	t.Run("dependency not built with dependency.New", func(t *testing.T) {
+		_, _, err := generate("./testdata/variable", "inject_gen.go")
+
+		assert.ErrorContains(t, err, "dependency `name` should be built with a `dependency.New` call to be generated")
+	})
+
+	t.Run("unknown command", func(t *testing.T) {
+		err := run([]string{"build"}, &bytes.Buffer{})
+
+		assert.ErrorContains(t, err, "usage: inject gen")
+	})
+}
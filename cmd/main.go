@@ -1,6 +1,48 @@
 package main
 
-// nolint
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `usage: inject gen [-o file] [package]
+
+gen finds the dependencies registered on the package and writes the code that builds them with no reflection.`
+
 func main() {
-	// Nothing to do here
+	if err := run(os.Args[1:], os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run executes the command given by the arguments.
+func run(args []string, stderr io.Writer) error {
+	if len(args) == 0 || args[0] != "gen" {
+		return errors.New(usage)
+	}
+
+	fs := flag.NewFlagSet("gen", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	output := fs.String("o", "inject_gen.go", "name of the generated file, relative to the package directory")
+
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	pattern := "."
+	if fs.NArg() > 0 {
+		pattern = fs.Arg(0)
+	}
+
+	path, src, err := generate(pattern, *output)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, src, 0o644)
 }
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/Drafteame/inject"
+	"github.com/Drafteame/inject/container"
+	"github.com/Drafteame/inject/dependency"
+	"github.com/Drafteame/inject/types"
+)
+
+type DB struct {
+	URL string
+}
+
+func NewDB(ctx context.Context, url string) (*DB, error) {
+	if url == "" {
+		return nil, errors.New("empty url")
+	}
+
+	return &DB{URL: url}, nil
+}
+
+type Repo struct {
+	DB     *DB
+	Prefix string
+}
+
+func NewRepo(db *DB, prefix string) *Repo {
+	return &Repo{DB: db, Prefix: prefix}
+}
+
+type Service struct {
+	Repo  *Repo
+	Cache types.Lazy[*Repo]
+}
+
+func NewService(repo *Repo, cache types.Lazy[*Repo]) *Service {
+	return &Service{Repo: repo, Cache: cache}
+}
+
+type Reports struct {
+	Source func() (io.Reader, error)
+	Token  func() (string, error)
+}
+
+func NewReports(source func() (io.Reader, error), token func() (string, error)) *Reports {
+	return &Reports{Source: source, Token: token}
+}
+
+func NewBuffer() *bytes.Buffer {
+	return bytes.NewBufferString("report")
+}
+
+func NewToken() func() (string, error) {
+	return func() (string, error) { return "token", nil }
+}
+
+const dbName = "db"
+
+func Register(c *container.Container) error {
+	if err := inject.Singleton(dbName, NewDB, strings.ToLower("postgres://main"), inject.WithDescription("main db")); err != nil {
+		return err
+	}
+
+	if err := inject.Provide("users.repo", NewRepo, dependency.Inject(dbName), func() string { return "users" }); err != nil {
+		return err
+	}
+
+	if err := inject.Provide("users.cache", dependency.NewSingleton(NewRepo, inject.Dep(dbName), "cache")); err != nil {
+		return err
+	}
+
+	if err := inject.Provide("reports.buffer", NewBuffer); err != nil {
+		return err
+	}
+
+	if err := inject.Provide("reports.token", NewToken); err != nil {
+		return err
+	}
+
+	if err := inject.Provide("reports", NewReports, dependency.Inject("reports.buffer"), dependency.Inject("reports.token")); err != nil {
+		return err
+	}
+
+	return c.Provide("users.service", dependency.New(NewService,
+		dependency.New(NewRepo, inject.Dep("db"), "cache"),
+		dependency.Inject("users.repo"),
+	))
+}
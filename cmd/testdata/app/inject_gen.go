@@ -0,0 +1,63 @@
+// Code generated by inject gen. DO NOT EDIT.
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// InjectGraph holds an instance of every dependency registered on the package.
+type InjectGraph struct {
+	// Db is the dependency `db`.
+	Db *DB
+	// UsersRepo is the dependency `users.repo`.
+	UsersRepo *Repo
+	// UsersCache is the dependency `users.cache`.
+	UsersCache *Repo
+	// ReportsBuffer is the dependency `reports.buffer`.
+	ReportsBuffer *bytes.Buffer
+	// ReportsToken is the dependency `reports.token`.
+	ReportsToken func() (string, error)
+	// Reports is the dependency `reports`.
+	Reports *Reports
+	// UsersService is the dependency `users.service`.
+	UsersService *Service
+}
+
+// BuildInjectGraph builds every dependency registered on the package with no reflection, after the ones they
+// inject. Each dependency is built once, and shared by every dependency that injects it.
+func BuildInjectGraph(ctx context.Context) (*InjectGraph, error) {
+	g := &InjectGraph{}
+
+	var err error
+
+	if g.Db, err = NewDB(ctx, strings.ToLower("postgres://main")); err != nil {
+		return nil, fmt.Errorf("inject: error constructing `%s`: %w", "db", err)
+	}
+
+	var v1 string
+
+	v1 = (func() string { return "users" })()
+
+	g.UsersRepo = NewRepo(g.Db, v1)
+
+	g.UsersCache = NewRepo(g.Db, "cache")
+
+	g.ReportsBuffer = NewBuffer()
+
+	g.ReportsToken = NewToken()
+
+	g.Reports = NewReports(func() (io.Reader, error) { return g.ReportsBuffer, nil }, g.ReportsToken)
+
+	var v2 *Repo
+
+	v2 = NewRepo(g.Db, "cache")
+
+	g.UsersService = NewService(v2, func() (*Repo, error) { return g.UsersRepo, nil })
+
+	return g, nil
+}
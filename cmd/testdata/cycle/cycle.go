@@ -0,0 +1,22 @@
+package cycle
+
+import (
+	"github.com/Drafteame/inject"
+	"github.com/Drafteame/inject/dependency"
+)
+
+type node struct {
+	next *node
+}
+
+func newNode(next *node) *node {
+	return &node{next: next}
+}
+
+func Register() error {
+	if err := inject.Provide("a", newNode, dependency.Inject("b")); err != nil {
+		return err
+	}
+
+	return inject.Provide("b", newNode, dependency.Inject("a"))
+}
@@ -0,0 +1,16 @@
+package variable
+
+import (
+	"github.com/Drafteame/inject"
+	"github.com/Drafteame/inject/dependency"
+)
+
+func newName() string {
+	return "name"
+}
+
+var name = dependency.New(newName)
+
+func Register() error {
+	return inject.Provide("name", name)
+}
@@ -0,0 +1,130 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Drafteame/inject/types"
+)
+
+// contextCloser is implemented by instances that need a context to be released.
+type contextCloser interface {
+	Close(ctx context.Context) error
+}
+
+// closeHook is a function provided to release an instance instead of its own `Close` method.
+type closeHook func(ctx context.Context, instance any) error
+
+// Close releases every singleton and scoped instance built by the container, in the reverse order they were built, so
+// each instance is closed before the instances it depends on. If the dependency was provided with an `OnClose`
+// function, it is called with the instance. Otherwise, instances that implement `Close(context.Context) error` or
+// `io.Closer` are closed, and the rest are just dropped. All closing errors are collected and returned together.
+//
+// After calling Close the container can't be used anymore, and every operation on it will fail. Singletons that are
+// still being built are closed as soon as they are built, and their callers get types.ErrClosed. Calling Close more
+// than once has no effect. Scopes created from the container can't resolve dependencies anymore either, but their
+// instances are not closed, so they should be closed on their own.
+func (c *Container) Close(ctx context.Context) error {
+	c.mu.Lock()
+
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+
+	c.closed = true
+	built, solved, hooks := c.takeBuilt()
+
+	c.mu.Unlock()
+
+	return closeInstances(ctx, built, solved, hooks)
+}
+
+// FlushAndClose is the same as Flush, but it closes every built singleton and scoped instance in the same way Close
+// does before deleting them. Unlike Close, the container can still be used afterwards.
+func (c *Container) FlushAndClose(ctx context.Context) error {
+	c.mu.Lock()
+	built, solved, hooks := c.takeBuilt()
+	c.mu.Unlock()
+
+	c.Flush()
+
+	return closeInstances(ctx, built, solved, hooks)
+}
+
+// checkOpen returns an error if the container, or any of its parents, was already closed.
+func (c *Container) checkOpen() error {
+	for _, scope := range c.chain() {
+		if scope.isClosed() {
+			return types.ErrClosed
+		}
+	}
+
+	return nil
+}
+
+func (c *Container) isClosed() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.closed
+}
+
+// takeBuilt removes the built instances from the container cache, returning them along with their build order and the
+// `OnClose` function of the ones that have it. It should be called holding the container lock.
+func (c *Container) takeBuilt() ([]types.Symbol, map[types.Symbol]any, map[types.Symbol]closeHook) {
+	built, solved := c.built, c.solvedDeps
+	hooks := make(map[types.Symbol]closeHook)
+
+	for _, name := range built {
+		dep, ok := c.deps[name]
+
+		if !ok && c.parent != nil {
+			dep, _, ok = c.parent.lookup(name)
+		}
+
+		if ok && dep.OnClose != nil {
+			hooks[name] = dep.OnClose
+		}
+	}
+
+	c.built = nil
+	c.solvedDeps = make(map[types.Symbol]any)
+
+	return built, solved, hooks
+}
+
+// closeInstances closes each built instance in the reverse order of the build order list. If the context is done
+// before all instances are closed, the remaining ones are skipped and the context error is returned with the rest.
+func closeInstances(ctx context.Context, built []types.Symbol, solved map[types.Symbol]any, hooks map[types.Symbol]closeHook) error {
+	errs := make(types.Errors, 0)
+
+	for i := len(built) - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, fmt.Errorf("inject: closing container: %w", err))
+			break
+		}
+
+		if err := closeInstance(ctx, solved[built[i]], hooks[built[i]]); err != nil {
+			errs = append(errs, fmt.Errorf("inject: error closing `%s`: %w", built[i], err))
+		}
+	}
+
+	return errs.ErrOrNil()
+}
+
+func closeInstance(ctx context.Context, instance any, hook closeHook) error {
+	if hook != nil {
+		return hook(ctx, instance)
+	}
+
+	switch closer := instance.(type) {
+	case contextCloser:
+		return closer.Close(ctx)
+	case io.Closer:
+		return closer.Close()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,259 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Drafteame/inject/dependency"
+	"github.com/Drafteame/inject/types"
+)
+
+type closer struct {
+	name   string
+	err    error
+	closed *[]string
+}
+
+func (c *closer) Close() error {
+	*c.closed = append(*c.closed, c.name)
+	return c.err
+}
+
+type ctxCloser struct {
+	closer
+}
+
+func (c *ctxCloser) Close(ctx context.Context) error {
+	if ctx == nil {
+		return errors.New("nil context")
+	}
+
+	return c.closer.Close()
+}
+
+func TestContainer_Close(t *testing.T) {
+	t.Run("close singletons in reverse build order", func(t *testing.T) {
+		ic := New()
+		closed := make([]string, 0)
+
+		newCloser := func(name string) func() *closer {
+			return func() *closer { return &closer{name: name, closed: &closed} }
+		}
+
+		if err := ic.Provide("db", dependency.NewSingleton(newCloser("db"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		repo := func(*closer) *ctxCloser { return &ctxCloser{closer{name: "repo", closed: &closed}} }
+
+		if err := ic.Provide("repo", dependency.NewSingleton(repo, dependency.Inject("db"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("transient", dependency.New(newCloser("transient"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("user", dependency.NewSingleton(newUser, "John", 21)); err != nil {
+			t.Error(err)
+			return
+		}
+
+		for _, name := range []types.Symbol{"repo", "transient", "user"} {
+			if _, err := ic.Get(name); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		err := ic.Close(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"repo", "db"}, closed)
+		assert.Empty(t, ic.solvedDeps)
+	})
+
+	t.Run("closed container can't be used", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("user", dependency.New(newUser, "John", 21)); err != nil {
+			t.Error(err)
+			return
+		}
+
+		assert.NoError(t, ic.Close(context.Background()))
+		assert.NoError(t, ic.Close(context.Background()))
+
+		_, err := ic.Get("user")
+		assert.Equal(t, types.ErrClosed, err)
+
+		err = ic.Provide("other", dependency.New(newUser, "John", 21))
+		assert.Equal(t, types.ErrClosed, err)
+
+		err = ic.Invoke(func() {})
+		assert.Equal(t, types.ErrClosed, err)
+	})
+
+	t.Run("scopes of a closed container can't be used", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("user", dependency.New(newUser, "John", 21)); err != nil {
+			t.Error(err)
+			return
+		}
+
+		scope := ic.Scope("request")
+
+		assert.NoError(t, ic.Close(context.Background()))
+
+		_, err := scope.Get("user")
+		assert.Equal(t, types.ErrClosed, err)
+
+		err = scope.Invoke(func() {})
+		assert.Equal(t, types.ErrClosed, err)
+
+		_, err = ic.Scope("request").Get("user")
+		assert.Equal(t, types.ErrClosed, err)
+
+		err = ic.Scope("request").Provide("other", dependency.New(newUser, "John", 21))
+		assert.Equal(t, types.ErrClosed, err)
+	})
+
+	t.Run("close singletons built after closing", func(t *testing.T) {
+		ic := New()
+		closed := make([]string, 0)
+		started, release := make(chan struct{}), make(chan struct{})
+
+		if err := ic.Provide("a", dependency.NewSingleton(func() *closer {
+			close(started)
+			<-release
+			return &closer{name: "a", closed: &closed}
+		})); err != nil {
+			t.Error(err)
+			return
+		}
+
+		errs := make(chan error, 1)
+
+		go func() {
+			_, err := ic.Get("a")
+			errs <- err
+		}()
+
+		<-started
+
+		assert.NoError(t, ic.Close(context.Background()))
+
+		close(release)
+
+		assert.Equal(t, types.ErrClosed, <-errs)
+		assert.Equal(t, []string{"a"}, closed)
+		assert.Empty(t, ic.solvedDeps)
+		assert.Empty(t, ic.built)
+	})
+
+	t.Run("collect every closing error", func(t *testing.T) {
+		ic := New()
+		closed := make([]string, 0)
+		errA := errors.New("a failed")
+		errB := errors.New("b failed")
+
+		if err := ic.Provide("a", dependency.NewSingleton(func() *closer { return &closer{name: "a", err: errA, closed: &closed} })); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("b", dependency.NewSingleton(func() *closer { return &closer{name: "b", err: errB, closed: &closed} })); err != nil {
+			t.Error(err)
+			return
+		}
+
+		for _, name := range []types.Symbol{"a", "b"} {
+			if _, err := ic.Get(name); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		err := ic.Close(context.Background())
+
+		assert.EqualError(t, err, "inject: 2 errors occurred: inject: error closing `b`: b failed; inject: error closing `a`: a failed")
+		assert.ErrorIs(t, err, errA)
+		assert.ErrorIs(t, err, errB)
+		assert.Equal(t, []string{"b", "a"}, closed)
+	})
+
+	t.Run("stop closing when context is done", func(t *testing.T) {
+		ic := New()
+		closed := make([]string, 0)
+
+		if err := ic.Provide("a", dependency.NewSingleton(func() *closer { return &closer{name: "a", closed: &closed} })); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if _, err := ic.Get("a"); err != nil {
+			t.Error(err)
+			return
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := ic.Close(ctx)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, closed)
+	})
+
+	t.Run("flush and close keeps container usable", func(t *testing.T) {
+		ic := New()
+		closed := make([]string, 0)
+
+		if err := ic.Provide("a", dependency.NewSingleton(func() *closer { return &closer{name: "a", closed: &closed} })); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if _, err := ic.Get("a"); err != nil {
+			t.Error(err)
+			return
+		}
+
+		err := ic.FlushAndClose(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a"}, closed)
+		assert.Empty(t, ic.deps)
+		assert.NoError(t, ic.Provide("a", dependency.New(newUser, "John", 21)))
+	})
+
+	t.Run("close scoped instances of a scope", func(t *testing.T) {
+		ic := New()
+		closed := make([]string, 0)
+
+		if err := ic.Provide("a", dependency.NewScoped(func() *closer { return &closer{name: "a", closed: &closed} })); err != nil {
+			t.Error(err)
+			return
+		}
+
+		scope := ic.Scope("request")
+
+		if _, err := scope.Get("a"); err != nil {
+			t.Error(err)
+			return
+		}
+
+		assert.NoError(t, scope.Close(context.Background()))
+		assert.Equal(t, []string{"a"}, closed)
+
+		_, err := ic.Get("a")
+		assert.NoError(t, err)
+	})
+}
@@ -1,14 +1,42 @@
 package container
 
 import (
+	"reflect"
+	"sync"
+
 	"github.com/Drafteame/inject/dependency"
 	"github.com/Drafteame/inject/types"
 )
 
-// Container is a dependency injection Container implementation
+// Container is a dependency injection Container implementation. It is safe for concurrent use, and each singleton
+// dependency is built just once even if many goroutines ask for it at the same time.
 type Container struct {
+	mu         sync.RWMutex
+	name       string
+	parent     *Container
 	solvedDeps map[types.Symbol]any
 	deps       map[types.Symbol]dependency.Dependency
+	byType     map[reflect.Type][]types.Symbol
+	groups     map[types.Symbol][]types.Symbol
+	decorators map[types.Symbol][]decorator
+	members    map[types.Symbol]membership
+	sources    map[types.Symbol]reflect.Type
+	inflight   map[types.Symbol]*singletonBuild
+	built      []types.Symbol
+	closed     bool
+	generation uint64
+	replaced   map[types.Symbol]uint64
+	seen       map[*Container]uint64
+}
+
+// singletonBuild represents a singleton that is being built by one goroutine. Other callers asking for the same
+// dependency wait on `done` and share the built value or error instead of calling the factory again.
+type singletonBuild struct {
+	name  types.Symbol
+	owner *resolution
+	done  chan struct{}
+	val   any
+	err   error
 }
 
 // New creates a new instance of a Container.
@@ -16,12 +44,28 @@ func New() *Container {
 	return &Container{
 		solvedDeps: make(map[types.Symbol]any),
 		deps:       make(map[types.Symbol]dependency.Dependency),
+		byType:     make(map[reflect.Type][]types.Symbol),
+		groups:     make(map[types.Symbol][]types.Symbol),
+		decorators: make(map[types.Symbol][]decorator),
+		members:    make(map[types.Symbol]membership),
+		sources:    make(map[types.Symbol]reflect.Type),
+		inflight:   make(map[types.Symbol]*singletonBuild),
 	}
 }
 
 // Flush WARNING: This function will delete all saved instances, solved and registered factories from the container.
 // Do not use this method on production, and just use it on testing purposes.
 func (c *Container) Flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.solvedDeps = make(map[types.Symbol]any)
 	c.deps = make(map[types.Symbol]dependency.Dependency)
+	c.byType = make(map[reflect.Type][]types.Symbol)
+	c.groups = make(map[types.Symbol][]types.Symbol)
+	c.decorators = make(map[types.Symbol][]decorator)
+	c.members = make(map[types.Symbol]membership)
+	c.sources = make(map[types.Symbol]reflect.Type)
+	c.inflight = make(map[types.Symbol]*singletonBuild)
+	c.built = nil
 }
@@ -1,7 +1,11 @@
 package container
 
 import (
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -24,3 +28,112 @@ func TestContainer_Flush(t *testing.T) {
 	assert.Empty(t, ic.deps)
 	assert.Empty(t, ic.solvedDeps)
 }
+
+func TestContainer_Concurrency(t *testing.T) {
+	t.Run("singleton is built once for concurrent callers", func(t *testing.T) {
+		ic := New()
+
+		var calls int32
+
+		factory := func() *driver {
+			atomic.AddInt32(&calls, 1)
+			time.Sleep(10 * time.Millisecond)
+			return newDriver("main")
+		}
+
+		if err := ic.Provide("driver", dependency.NewSingleton(factory)); err != nil {
+			t.Error(err)
+			return
+		}
+
+		const callers = 50
+
+		results := make([]any, callers)
+		errs := make([]error, callers)
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+				results[i], errs[i] = ic.Get("driver")
+			}(i)
+		}
+
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+		for i := 0; i < callers; i++ {
+			if assert.NoError(t, errs[i]) {
+				assert.Same(t, results[0], results[i])
+			}
+		}
+	})
+
+	t.Run("building a singleton does not block unrelated dependencies", func(t *testing.T) {
+		ic := New()
+
+		release := make(chan struct{})
+		started := make(chan struct{})
+
+		slow := func() *driver {
+			close(started)
+			<-release
+			return newDriver("slow")
+		}
+
+		if err := ic.Provide("slow", dependency.NewSingleton(slow)); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("fast", dependency.NewSingleton(newDriver, "fast")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		go func() {
+			_, _ = ic.Get("slow")
+		}()
+
+		<-started
+
+		val, err := ic.Get("fast")
+
+		close(release)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "fast", val.(*driver).client())
+	})
+
+	t.Run("concurrent provide, get and invoke", func(t *testing.T) {
+		ic := New()
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				name := types.Symbol(fmt.Sprintf("driver%d", i))
+
+				assert.NoError(t, ic.Provide(name, dependency.NewSingleton(newDriver, string(name))))
+
+				_, err := ic.Get(name)
+				assert.NoError(t, err)
+
+				assert.NoError(t, ic.Invoke(func() {}))
+			}(i)
+		}
+
+		wg.Wait()
+
+		assert.Len(t, ic.deps, 20)
+		assert.Len(t, ic.solvedDeps, 20)
+	})
+}
@@ -0,0 +1,139 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/Drafteame/inject/dependency"
+	"github.com/Drafteame/inject/types"
+	"github.com/Drafteame/inject/utils"
+)
+
+// decorator is a function that receives a built instance of a dependency, along with its own arguments, and returns a
+// replacement for it.
+type decorator struct {
+	fn   any
+	args []any
+}
+
+// Decorate registers a decorator for the dependency of the given name. The decorator should be a function that
+// receives the built instance as its first parameter and returns a replacement of a compatible type, and optionally an
+// error as its last return value, e.g:
+//
+// func(l *Logger, prefix string) *Logger
+//
+// The rest of the decorator parameters are filled with the provided arguments, that can be any value accepted as a
+// dependency argument, like `dependency.Inject(name)`. Decorators are applied in registration order every time the
+// dependency is built, so a singleton is decorated only once before it is cached.
+//
+// Decorators registered on a scope are applied to the instances built by that scope, so they don't affect singletons
+// provided on its parents.
+func (c *Container) Decorate(name types.Symbol, fn any, args ...any) error {
+	dep, ok := c.Lookup(name)
+	if !ok {
+		return &types.NotFoundError{Name: name}
+	}
+
+	if err := checkDecorator(utils.GetFirstReturnType(dep.Factory), fn, args); err != nil {
+		return fmt.Errorf("inject: invalid decorator for `%s`: %w", name, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return types.ErrClosed
+	}
+
+	if _, ok := c.solvedDeps[name]; ok {
+		return fmt.Errorf("inject: dependency `%s` was already built and can't be decorated", name)
+	}
+
+	if c.decorators == nil {
+		c.decorators = make(map[types.Symbol][]decorator)
+	}
+
+	c.decorators[name] = append(c.decorators[name], decorator{fn: fn, args: args})
+
+	return nil
+}
+
+// decorate applies every decorator registered for the dependency, on the container or any of its parents, starting by
+// the ones registered on the root container.
+func (c *Container) decorate(name types.Symbol, val any, r resolver) (any, error) {
+	for _, d := range c.decoratorsOf(name) {
+		dep := d.dependency(reflect.TypeOf(d.fn).In(0), val)
+
+		res, err := dep.SetContainer(r).BuildContext(r.ctx)
+		if err != nil {
+			return nil, fmt.Errorf("inject: error decorating `%s`: %w", name, err)
+		}
+
+		val = res
+	}
+
+	return val, nil
+}
+
+func (c *Container) decoratorsOf(name types.Symbol) []decorator {
+	decorators := make([]decorator, 0)
+	chain := c.chain()
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		chain[i].mu.RLock()
+		decorators = append(decorators, chain[i].decorators[name]...)
+		chain[i].mu.RUnlock()
+	}
+
+	return decorators
+}
+
+// dependency returns the decorator as a dependency that receives the given value as its first argument.
+func (d decorator) dependency(vtype reflect.Type, val any) dependency.Dependency {
+	args := make([]any, 0, len(d.args)+1)
+	args = append(args, dependency.New(valueFactory(vtype, val)))
+	args = append(args, d.args...)
+
+	return dependency.New(d.fn, args...)
+}
+
+// checkDecorator validates the signature of the decorator against the return type of the decorated dependency.
+func checkDecorator(rt reflect.Type, fn any, args []any) error {
+	ftype := reflect.TypeOf(fn)
+
+	if ftype == nil || ftype.Kind() != reflect.Func {
+		return fmt.Errorf("inject: decorator should be a function, got `%v`", ftype)
+	}
+
+	if ftype.NumIn() != len(args)+1 {
+		return fmt.Errorf("inject: invalid argument length for decorator `%v`, got %d (need %d)", ftype, len(args), ftype.NumIn()-1)
+	}
+
+	if ftype.NumOut() == 0 {
+		return fmt.Errorf("inject: decorator `%v` should return a replacement value", ftype)
+	}
+
+	if !rt.AssignableTo(ftype.In(0)) {
+		return fmt.Errorf("inject: decorator `%v` can't receive a value of type `%v`", ftype, rt)
+	}
+
+	if !ftype.Out(0).AssignableTo(rt) {
+		return fmt.Errorf("inject: decorator `%v` should return a value assignable to `%v`", ftype, rt)
+	}
+
+	return nil
+}
+
+// valueFactory creates a function with no arguments that returns the provided value as the given type.
+func valueFactory(vtype reflect.Type, val any) any {
+	ftype := reflect.FuncOf(nil, []reflect.Type{vtype}, false)
+
+	value := reflect.New(vtype).Elem()
+	if val != nil {
+		value.Set(reflect.ValueOf(val))
+	}
+
+	return reflect.MakeFunc(ftype, func([]reflect.Value) []reflect.Value {
+		return []reflect.Value{value}
+	}).Interface()
+}
@@ -0,0 +1,181 @@
+package container
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Drafteame/inject/dependency"
+	"github.com/Drafteame/inject/types"
+)
+
+type prefixed struct {
+	database
+	prefix string
+}
+
+func (p prefixed) client() string {
+	return p.prefix + p.database.client()
+}
+
+func prefix(db database, value string) database {
+	return prefixed{database: db, prefix: value}
+}
+
+func TestContainer_Decorate(t *testing.T) {
+	t.Run("decorators stack in registration order", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("driver", dependency.New(func() database { return newDriver("main") })); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("prefix", dependency.New(func() string { return "b:" })); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Decorate("driver", prefix, "a:"); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Decorate("driver", prefix, dependency.Inject("prefix")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		val, err := ic.Get("driver")
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, "b:a:main", val.(database).client())
+		}
+	})
+
+	t.Run("singletons are decorated once", func(t *testing.T) {
+		ic := New()
+		calls := 0
+
+		if err := ic.Provide("user", dependency.NewSingleton(newUser, "John", 21)); err != nil {
+			t.Error(err)
+			return
+		}
+
+		decorator := func(u *user) (*user, error) {
+			calls++
+			u.name = "Mr. " + u.name
+			return u, nil
+		}
+
+		if err := ic.Decorate("user", decorator); err != nil {
+			t.Error(err)
+			return
+		}
+
+		first, err := ic.Get("user")
+		assert.NoError(t, err)
+
+		second, err := ic.Get("user")
+		assert.NoError(t, err)
+
+		assert.Same(t, first, second)
+		assert.Equal(t, "Mr. John", second.(*user).getName())
+		assert.Equal(t, 1, calls)
+
+		err = ic.Decorate("user", decorator)
+
+		assert.EqualError(t, err, "inject: dependency `user` was already built and can't be decorated")
+	})
+
+	t.Run("decorator error", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("user", dependency.New(newUser, "John", 21)); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Decorate("user", func(*user) (*user, error) { return nil, errors.New("some") }); err != nil {
+			t.Error(err)
+			return
+		}
+
+		_, err := ic.Get("user")
+
+		assert.EqualError(t, err, "inject: error decorating `user`: inject: error constructing `func(*container.user) (*container.user, error)`: some")
+	})
+
+	t.Run("invalid decorators", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("user", dependency.New(newUser, "John", 21)); err != nil {
+			t.Error(err)
+			return
+		}
+
+		tests := map[string]struct {
+			name   types.Symbol
+			fn     any
+			args   []any
+			expErr string
+		}{
+			"missing dependency": {
+				name:   "other",
+				fn:     func(*user) *user { return nil },
+				expErr: "inject: no provided dependency of name `other`",
+			},
+			"non function": {
+				name:   "user",
+				fn:     10,
+				expErr: "inject: invalid decorator for `user`: inject: decorator should be a function, got `int`",
+			},
+			"wrong argument length": {
+				name:   "user",
+				fn:     func(*user, string) *user { return nil },
+				expErr: "inject: invalid decorator for `user`: inject: invalid argument length for decorator `func(*container.user, string) *container.user`, got 0 (need 1)",
+			},
+			"no return value": {
+				name:   "user",
+				fn:     func(*user) {},
+				expErr: "inject: invalid decorator for `user`: inject: decorator `func(*container.user)` should return a replacement value",
+			},
+			"incompatible input": {
+				name:   "user",
+				fn:     func(database) *user { return nil },
+				expErr: "inject: invalid decorator for `user`: inject: decorator `func(container.database) *container.user` can't receive a value of type `*container.user`",
+			},
+			"incompatible output": {
+				name:   "user",
+				fn:     func(*user) namer { return nil },
+				expErr: "inject: invalid decorator for `user`: inject: decorator `func(*container.user) container.namer` should return a value assignable to `*container.user`",
+			},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				err := ic.Decorate(test.name, test.fn, test.args...)
+				assert.EqualError(t, err, test.expErr)
+			})
+		}
+	})
+
+	t.Run("validate decorator arguments", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("driver", dependency.New(func() database { return newDriver("main") })); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Decorate("driver", prefix, dependency.Inject("prefix")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		err := ic.Validate()
+
+		assert.EqualError(t, err, "inject: invalid decorator for `driver`: inject: error resolving argument 1 for constructor func(container.database, string) container.database: inject: no provided dependency of name `prefix`")
+	})
+}
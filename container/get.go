@@ -1,7 +1,10 @@
 package container
 
 import (
+	"context"
 	"fmt"
+	"reflect"
+	"sync"
 
 	"github.com/Drafteame/inject/dependency"
 	"github.com/Drafteame/inject/types"
@@ -11,39 +14,189 @@ import (
 // type will depend on the dependency configuration, if it was marked as a singleton or not. If it was, the builder will
 // try to return a previously created instance of that dependency instead of just create a new instance.
 func (c *Container) Get(name types.Symbol) (any, error) {
-	dep, ok := c.deps[name]
-	if !ok {
-		return nil, fmt.Errorf("inject: no provided dependency of name `%s`", name)
-	}
+	return c.GetContext(context.Background(), name)
+}
 
-	if dep.IsSingleton() {
-		return c.getSingleton(name, dep)
+// GetContext is the same as Get, but the context is passed to every factory of the dependency three that receives a
+// context.Context as first parameter. If the context is done before the next factory is called, the build stops and
+// the context error is returned.
+func (c *Container) GetContext(ctx context.Context, name types.Symbol) (any, error) {
+	r := c.newResolver(ctx)
+
+	val, err := r.Get(name)
+	if err != nil {
+		return nil, r.err(err)
 	}
 
-	return c.getInstance(dep)
+	return val, nil
 }
 
-func (c *Container) getSingleton(name types.Symbol, dep dependency.Dependency) (any, error) {
-	val, ok := c.solvedDeps[name]
-	if ok {
+// getSingleton returns the cached instance of a singleton dependency. If the instance was not built yet, the first
+// caller registers an in-flight build and constructs it without holding the container lock, so unrelated dependencies
+// can still be resolved meanwhile. Concurrent callers of the same dependency wait for that build and get its result.
+func (c *Container) getSingleton(name types.Symbol, dep dependency.Dependency, r resolver) (any, error) {
+	if c.parent != nil {
+		c.dropReplaced()
+	}
+
+	c.mu.Lock()
+
+	if val, ok := c.solvedDeps[name]; ok {
+		c.mu.Unlock()
 		return val, nil
 	}
 
-	val, err := c.getInstance(dep)
-	if err != nil {
-		return nil, err
+	if build, ok := c.inflight[name]; ok {
+		c.mu.Unlock()
+
+		if err := r.wait(build); err != nil {
+			return nil, err
+		}
+
+		defer r.stopWaiting()
+
+		select {
+		case <-build.done:
+			return build.val, build.err
+		case <-r.ctx.Done():
+			return nil, fmt.Errorf("inject: context done while waiting for singleton `%s`: %w", name, r.ctx.Err())
+		}
 	}
 
-	c.solvedDeps[name] = val
+	build := &singletonBuild{name: name, owner: r.state, done: make(chan struct{})}
 
-	return val, nil
+	if c.inflight == nil {
+		c.inflight = make(map[types.Symbol]*singletonBuild)
+	}
+
+	c.inflight[name] = build
+	c.mu.Unlock()
+
+	// If the factory panics, waiting callers are released with this error and nothing is cached.
+	build.err = fmt.Errorf("inject: singleton `%s` build was interrupted", name)
+
+	func() {
+		defer c.finishSingleton(name, dep, build)
+		build.val, build.err = c.getInstance(name, dep, r)
+	}()
+
+	return build.val, build.err
 }
 
-func (c *Container) getInstance(dep dependency.Dependency) (any, error) {
-	val, err := dep.SetContainer(c).Build()
+// finishSingleton saves the result of an in-flight singleton build and releases every caller waiting on it. Failed
+// builds are not cached, so the next call will try to build the dependency again. If the container was flushed while
+// the build was running, the result is discarded. If it was closed, the instance is closed right away and the build
+// fails with types.ErrClosed, so it is not leaked.
+func (c *Container) finishSingleton(name types.Symbol, dep dependency.Dependency, build *singletonBuild) {
+	defer close(build.done)
+
+	c.mu.Lock()
+
+	if c.inflight[name] != build {
+		c.mu.Unlock()
+		return
+	}
+
+	delete(c.inflight, name)
+
+	if build.err != nil {
+		c.mu.Unlock()
+		return
+	}
+
+	if c.closed {
+		c.mu.Unlock()
+		c.discardClosed(name, dep, build)
+
+		return
+	}
+
+	if c.solvedDeps == nil {
+		c.solvedDeps = make(map[types.Symbol]any)
+	}
+
+	c.solvedDeps[name] = build.val
+	c.built = append(c.built, name)
+	c.mu.Unlock()
+}
+
+// discardClosed closes the instance of a singleton build that finished after the container was closed, and makes the
+// build fail with types.ErrClosed.
+func (c *Container) discardClosed(name types.Symbol, dep dependency.Dependency, build *singletonBuild) {
+	val := build.val
+	build.val, build.err = nil, types.ErrClosed
+
+	if err := closeInstance(context.Background(), val, dep.OnClose); err != nil {
+		build.err = types.Errors{types.ErrClosed, fmt.Errorf("inject: error closing `%s`: %w", name, err)}
+	}
+}
+
+// getInstance builds a new instance of the dependency, resolving its injected arguments through the given resolver, and
+// applies every decorator registered for it.
+func (c *Container) getInstance(name types.Symbol, dep dependency.Dependency, r resolver) (any, error) {
+	val, err := dep.SetContainer(r).BuildContext(r.ctx)
 	if err != nil {
-		return nil, fmt.Errorf("inject: error building dependency instance: %v", err)
+		return nil, &types.BuildError{
+			Symbol:  name,
+			Factory: reflect.TypeOf(dep.Factory),
+			Path:    r.copyPath(),
+			Err:     err,
+		}
 	}
 
-	return val, nil
+	return c.decorate(name, val, r)
+}
+
+// waits guards the builds that each resolution is waiting for, so wait cycles between concurrent resolutions are found
+// before they block forever.
+var waits sync.Mutex
+
+// wait registers that the resolution is going to wait for an in-flight singleton build. If the resolution that runs the
+// build is waiting, directly or through other resolutions, for a build of this one, waiting would block both forever,
+// so a types.CycleError is returned instead. The path of the cycle joins the build paths of every resolution on
+// it.
+func (r resolver) wait(build *singletonBuild) error {
+	waits.Lock()
+	defer waits.Unlock()
+
+	path := r.copyPath()
+
+	for b := build; b != nil; b = b.owner.waiting {
+		if b.owner == r.state {
+			cycle := &types.CycleError{Path: path}
+
+			if r.state.cycle == nil {
+				r.state.cycle = cycle
+			}
+
+			return cycle
+		}
+
+		path = append(path, pathAfter(b.owner.waitPath, b.name)...)
+	}
+
+	r.state.waiting = build
+	r.state.waitPath = r.copyPath()
+
+	return nil
+}
+
+// stopWaiting clears the build the resolution was waiting for.
+func (r resolver) stopWaiting() {
+	waits.Lock()
+	defer waits.Unlock()
+
+	r.state.waiting = nil
+	r.state.waitPath = nil
+}
+
+// pathAfter returns the symbols of the path that follow the given one.
+func pathAfter(path []types.Symbol, name types.Symbol) []types.Symbol {
+	for i, symbol := range path {
+		if symbol == name {
+			return path[i+1:]
+		}
+	}
+
+	return nil
 }
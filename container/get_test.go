@@ -0,0 +1,296 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Drafteame/inject/dependency"
+	"github.com/Drafteame/inject/types"
+)
+
+func TestContainer_Get(t *testing.T) {
+	t.Run("get dependency instance", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("user", dependency.New(newUser, "John", 21)); err != nil {
+			t.Error(err)
+			return
+		}
+
+		val, err := ic.Get("user")
+
+		if assert.NoError(t, err) && assert.IsType(t, &user{}, val) {
+			assert.Equal(t, "John", val.(*user).getName())
+		}
+	})
+
+	t.Run("get not provided dependency", func(t *testing.T) {
+		ic := New()
+
+		_, err := ic.Get("user")
+
+		expErr := errors.New("inject: no provided dependency of name `user`")
+
+		assert.Error(t, err)
+		assert.EqualError(t, err, expErr.Error())
+		assert.ErrorIs(t, err, types.ErrNotFound)
+	})
+
+	t.Run("tell failing constructor from missing dependency", func(t *testing.T) {
+		ic := New()
+		errConn := errors.New("connection refused")
+
+		if err := ic.Provide("driver", dependency.NewSingleton(func() (*driver, error) { return nil, errConn })); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("user", dependency.New(newUserWithDriver, dependency.Inject("driver"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		_, err := ic.Get("user")
+
+		assert.ErrorIs(t, err, errConn)
+		assert.NotErrorIs(t, err, types.ErrNotFound)
+
+		var buildErr *types.BuildError
+
+		if assert.ErrorAs(t, err, &buildErr) {
+			assert.Equal(t, types.Symbol("user"), buildErr.Symbol)
+			assert.Equal(t, []types.Symbol{"user"}, buildErr.Path)
+			assert.Equal(t, "func(container.database) *container.user", buildErr.Factory.String())
+		}
+
+		if assert.ErrorAs(t, buildErr.Err, &buildErr) {
+			assert.Equal(t, types.Symbol("driver"), buildErr.Symbol)
+			assert.Equal(t, []types.Symbol{"user", "driver"}, buildErr.Path)
+		}
+
+		if err := ic.Provide("todo", dependency.New(newTodo, dependency.Inject("missing"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		_, err = ic.Get("todo")
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+		assert.NotErrorIs(t, err, errConn)
+	})
+
+	t.Run("detect cycle between injectable arguments", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("a", dependency.New(newUserWithDriver, dependency.Inject("b"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("b", dependency.New(newUser, dependency.Inject("a"), 21)); err != nil {
+			t.Error(err)
+			return
+		}
+
+		_, err := ic.Get("a")
+
+		var cycle *types.CycleError
+
+		if assert.ErrorAs(t, err, &cycle) {
+			assert.Equal(t, []types.Symbol{"a", "b", "a"}, cycle.Path)
+			assert.EqualError(t, err, "inject: dependency cycle detected: a -> b -> a")
+		}
+	})
+
+	t.Run("detect cycle of singleton on itself", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("a", dependency.NewSingleton(newUserWithDriver, dependency.Inject("a"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		_, err := ic.Get("a")
+
+		assert.EqualError(t, err, "inject: dependency cycle detected: a -> a")
+
+		_, err = ic.Get("a")
+
+		assert.EqualError(t, err, "inject: dependency cycle detected: a -> a")
+	})
+
+	t.Run("detect cycle through nested dependency", func(t *testing.T) {
+		ic := New()
+
+		nested := dependency.New(newDriver, dependency.New(func(u *user) string { return u.getName() }, dependency.Inject("a")))
+
+		if err := ic.Provide("a", dependency.New(newUserWithDriver, dependency.Inject("b"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("b", nested); err != nil {
+			t.Error(err)
+			return
+		}
+
+		_, err := ic.Get("b")
+
+		assert.EqualError(t, err, "inject: dependency cycle detected: b -> a -> b")
+	})
+
+	t.Run("detect cycle from invoke fields", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("a", dependency.New(newUserWithDriver, dependency.Inject("b"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("b", dependency.New(newDriver, dependency.Inject("c"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("c", dependency.New(func(u *user) string { return u.getName() }, dependency.Inject("a"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		type args struct {
+			types.In
+			User *user `inject:"name=a"`
+		}
+
+		err := ic.Invoke(func(in args) {})
+
+		var cycle *types.CycleError
+
+		if assert.ErrorAs(t, err, &cycle) {
+			assert.Equal(t, []types.Symbol{"a", "b", "c", "a"}, cycle.Path)
+		}
+	})
+
+	t.Run("detect cycle between concurrent singleton builds", func(t *testing.T) {
+		ic := New()
+
+		var started sync.WaitGroup
+		started.Add(2)
+
+		// Each build waits on the gate until the other one is in flight too.
+		gate := dependency.New(func() string {
+			started.Done()
+			started.Wait()
+			return "gate"
+		})
+
+		if err := ic.Provide("a", dependency.NewSingleton(func(_ string, db database) *user { return newUserWithDriver(db) }, gate, dependency.Inject("b"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("b", dependency.NewSingleton(func(_ string, u *user) *driver { return newDriver(u.getName()) }, gate, dependency.Inject("a"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		errs := make(chan error, 2)
+
+		for _, name := range []types.Symbol{"a", "b"} {
+			go func(name types.Symbol) {
+				_, err := ic.Get(name)
+				errs <- err
+			}(name)
+		}
+
+		for i := 0; i < 2; i++ {
+			select {
+			case err := <-errs:
+				assert.ErrorIs(t, err, types.ErrCycle)
+			case <-time.After(time.Second):
+				t.Fatal("concurrent singleton builds are blocked on each other")
+			}
+		}
+	})
+}
+
+func TestContainer_GetContext(t *testing.T) {
+	type ctxKey struct{}
+
+	t.Run("pass context to the dependency three", func(t *testing.T) {
+		ic := New()
+		ctx := context.WithValue(context.Background(), ctxKey{}, "main")
+
+		if err := ic.Provide("driver", dependency.NewSingleton(func(ctx context.Context) *driver {
+			return newDriver(ctx.Value(ctxKey{}).(string))
+		})); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("user", dependency.New(newUserWithDriver, dependency.Inject("driver"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		val, err := ic.GetContext(ctx, "user")
+
+		if assert.NoError(t, err) && assert.IsType(t, &user{}, val) {
+			assert.Equal(t, "main", val.(*user).getDb().client())
+		}
+	})
+
+	t.Run("stop build on canceled context", func(t *testing.T) {
+		ic := New()
+		ctx, cancel := context.WithCancel(context.Background())
+		called := false
+
+		if err := ic.Provide("driver", dependency.New(func() *driver {
+			cancel()
+			return newDriver("main")
+		})); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("user", dependency.New(func(ctx context.Context, db database) *user {
+			called = true
+			return newUserWithDriver(db)
+		}, dependency.Inject("driver"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		_, err := ic.GetContext(ctx, "user")
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.False(t, called)
+	})
+
+	t.Run("canceled singleton build is not cached", func(t *testing.T) {
+		ic := New()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if err := ic.Provide("driver", dependency.NewSingleton(func(ctx context.Context) *driver {
+			return newDriver("main")
+		})); err != nil {
+			t.Error(err)
+			return
+		}
+
+		_, err := ic.GetContext(ctx, "driver")
+
+		assert.ErrorIs(t, err, context.Canceled)
+
+		val, err := ic.Get("driver")
+
+		assert.NoError(t, err)
+		assert.IsType(t, &driver{}, val)
+	})
+}
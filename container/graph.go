@@ -0,0 +1,276 @@
+package container
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Drafteame/inject/dependency"
+	"github.com/Drafteame/inject/types"
+)
+
+// Graph is a description of how the dependencies of a container are wired. It can be exported as Graphviz DOT, Mermaid
+// or JSON. Nodes and edges are sorted, so the output is stable and can be committed and diffed.
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// GraphNode is a provided dependency, or a nested dependency used as an argument of another one. Nested dependencies
+// are identified by the id of the dependency that uses them followed by the argument index, e.g. `user/0`.
+type GraphNode struct {
+	ID          string `json:"id"`
+	Factory     string `json:"factory"`
+	Description string `json:"description,omitempty"`
+	Singleton   bool   `json:"singleton"`
+	Scoped      bool   `json:"scoped"`
+	Built       bool   `json:"built"`
+	Nested      bool   `json:"nested"`
+}
+
+// GraphEdge goes from a dependency to the dependency that is used as its argument on the given index.
+type GraphEdge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Argument int    `json:"argument"`
+}
+
+// Graph returns the description of every dependency that can be resolved from the container, with an edge for each
+// Injectable or nested Dependency argument. The dependencies of a multi-output factory are shown with the type of the
+// factory, and each extra output has an edge to the dependency that declares it. No factory is called to build it.
+func (c *Container) Graph() Graph {
+	g := Graph{
+		Nodes: make([]GraphNode, 0),
+		Edges: make([]GraphEdge, 0),
+	}
+
+	for _, name := range c.visibleSymbols() {
+		if _, ok := c.sourceFactory(name); ok {
+			continue
+		}
+
+		dep, owner, _ := c.lookup(name)
+
+		node := GraphNode{
+			ID:          string(name),
+			Factory:     factoryName(dep),
+			Description: dep.Description,
+			Singleton:   dep.IsSingleton(),
+			Scoped:      dep.IsScoped(),
+			Built:       c.isBuilt(name, dep, owner),
+		}
+
+		source, ftype, ok := c.outputSource(dep)
+
+		switch {
+		case !ok:
+			g.addArguments(string(name), dep)
+		case source == sourceName(name):
+			node.Factory = ftype.String()
+			sdep, _, _ := c.lookup(source)
+			g.addArguments(string(name), sdep)
+		default:
+			node.Factory = ftype.String()
+			g.Edges = append(g.Edges, GraphEdge{From: string(name), To: string(declarerOf(source)), Argument: 0})
+		}
+
+		g.Nodes = append(g.Nodes, node)
+	}
+
+	sort.SliceStable(g.Nodes, func(i, j int) bool { return g.Nodes[i].ID < g.Nodes[j].ID })
+
+	sort.SliceStable(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+
+		return g.Edges[i].Argument < g.Edges[j].Argument
+	})
+
+	return g
+}
+
+// addArguments adds an edge for each argument of the dependency that references other dependency, and a node for each
+// nested dependency.
+func (g *Graph) addArguments(id string, dep dependency.Dependency) {
+	for i, arg := range dep.Args {
+		switch a := arg.(type) {
+		case dependency.Injectable:
+			g.Edges = append(g.Edges, GraphEdge{From: id, To: string(a.Name()), Argument: i})
+		case dependency.Dependency:
+			nested := fmt.Sprintf("%s/%d", id, i)
+
+			g.Nodes = append(g.Nodes, GraphNode{
+				ID:        nested,
+				Factory:   factoryName(a),
+				Singleton: a.IsSingleton(),
+				Scoped:    a.IsScoped(),
+				Nested:    true,
+			})
+
+			g.Edges = append(g.Edges, GraphEdge{From: id, To: nested, Argument: i})
+			g.addArguments(nested, a)
+		}
+	}
+}
+
+// outputSource returns the name of the internal output that the dependency reads its value from, along with the type
+// of the multi-output factory it calls, and false if the dependency is not part of a multi-output factory.
+func (c *Container) outputSource(dep dependency.Dependency) (types.Symbol, reflect.Type, bool) {
+	if len(dep.Args) != 1 {
+		return "", nil, false
+	}
+
+	arg, ok := dep.Args[0].(dependency.Injectable)
+	if !ok {
+		return "", nil, false
+	}
+
+	ftype, ok := c.sourceFactory(arg.Name())
+
+	return arg.Name(), ftype, ok
+}
+
+// isBuilt returns true if there is a cached instance of the dependency, on the container that should keep it.
+func (c *Container) isBuilt(name types.Symbol, dep dependency.Dependency, owner *Container) bool {
+	cache := owner
+
+	switch {
+	case dep.IsScoped():
+		cache = c
+	case !dep.IsSingleton():
+		return false
+	}
+
+	cache.mu.RLock()
+	defer cache.mu.RUnlock()
+
+	_, ok := cache.solvedDeps[name]
+
+	return ok
+}
+
+// JSON returns the graph as an indented JSON document.
+func (g Graph) JSON() ([]byte, error) {
+	return json.MarshalIndent(g, "", "  ")
+}
+
+// DOT returns the graph on the Graphviz DOT language. Singletons are drawn with a bold border, scoped dependencies with
+// a double border and nested dependencies with a dashed one. Descriptions are shown as tooltips.
+func (g Graph) DOT() string {
+	var sb strings.Builder
+
+	sb.WriteString("digraph inject {\n")
+	sb.WriteString("\tnode [shape=box];\n")
+
+	for _, node := range g.Nodes {
+		label := strings.Join(append([]string{node.ID, node.Factory}, node.annotations()...), "\\n")
+		attrs := []string{fmt.Sprintf("label=%s", dotQuote(label))}
+
+		switch {
+		case node.Singleton:
+			attrs = append(attrs, "style=bold")
+		case node.Scoped:
+			attrs = append(attrs, "peripheries=2")
+		case node.Nested:
+			attrs = append(attrs, "style=dashed")
+		}
+
+		if node.Description != "" {
+			attrs = append(attrs, fmt.Sprintf("tooltip=%s", dotQuote(node.Description)))
+		}
+
+		sb.WriteString(fmt.Sprintf("\t%s [%s];\n", dotQuote(node.ID), strings.Join(attrs, ", ")))
+	}
+
+	for _, edge := range g.Edges {
+		sb.WriteString(fmt.Sprintf("\t%s -> %s [label=\"%d\"];\n", dotQuote(edge.From), dotQuote(edge.To), edge.Argument))
+	}
+
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// Mermaid returns the graph as a Mermaid flowchart. Since symbols can contain characters that are not valid on Mermaid
+// identifiers, nodes are identified by its position and the symbol is used on the label. Edges to dependencies that
+// were not provided point to a node with the `missing` annotation.
+func (g Graph) Mermaid() string {
+	var sb strings.Builder
+
+	ids := make(map[string]string)
+	nodes := append([]GraphNode{}, g.Nodes...)
+
+	for _, edge := range g.Edges {
+		if !containsNode(nodes, edge.To) {
+			nodes = append(nodes, GraphNode{ID: edge.To, Factory: "missing"})
+		}
+	}
+
+	sb.WriteString("graph TD\n")
+
+	for i, node := range nodes {
+		ids[node.ID] = fmt.Sprintf("n%d", i)
+		label := strings.Join(append([]string{node.ID, node.Factory}, node.annotations()...), "<br/>")
+
+		sb.WriteString(fmt.Sprintf("\t%s[\"%s\"]\n", ids[node.ID], mermaidEscape(label)))
+	}
+
+	for _, edge := range g.Edges {
+		sb.WriteString(fmt.Sprintf("\t%s -->|%d| %s\n", ids[edge.From], edge.Argument, ids[edge.To]))
+	}
+
+	return sb.String()
+}
+
+// annotations returns the lifetime and build status of the node, to be used on labels.
+func (n GraphNode) annotations() []string {
+	annotations := make([]string, 0, 2)
+
+	switch {
+	case n.Singleton:
+		annotations = append(annotations, "singleton")
+	case n.Scoped:
+		annotations = append(annotations, "scoped")
+	}
+
+	if n.Built {
+		annotations = append(annotations, "built")
+	}
+
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	return []string{strings.Join(annotations, ", ")}
+}
+
+func containsNode(nodes []GraphNode, id string) bool {
+	for _, node := range nodes {
+		if node.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func factoryName(dep dependency.Dependency) string {
+	ftype := reflect.TypeOf(dep.Factory)
+	if ftype == nil {
+		return "nil"
+	}
+
+	return ftype.String()
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+func mermaidEscape(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
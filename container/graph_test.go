@@ -0,0 +1,179 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Drafteame/inject/dependency"
+)
+
+func newGraphContainer(t *testing.T) *Container {
+	ic := New()
+
+	if err := ic.Provide("driver", dependency.NewSingleton(newDriver, "main")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ic.Provide("user", dependency.New(newUserWithDriver, dependency.Inject("driver"))); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ic.Provide("todo", dependency.New(newTodo, dependency.New(newDriver, "aux"))); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ic.Get("user"); err != nil {
+		t.Fatal(err)
+	}
+
+	return ic
+}
+
+func TestContainer_Graph(t *testing.T) {
+	g := newGraphContainer(t).Graph()
+
+	expected := Graph{
+		Nodes: []GraphNode{
+			{ID: "driver", Factory: "func(string) *container.driver", Singleton: true, Built: true},
+			{ID: "todo", Factory: "func(container.database) *container.todo"},
+			{ID: "todo/0", Factory: "func(string) *container.driver", Nested: true},
+			{ID: "user", Factory: "func(container.database) *container.user"},
+		},
+		Edges: []GraphEdge{
+			{From: "todo", To: "todo/0", Argument: 0},
+			{From: "user", To: "driver", Argument: 0},
+		},
+	}
+
+	assert.Equal(t, expected, g)
+}
+
+func TestContainer_GraphMultiOutput(t *testing.T) {
+	ic := New()
+
+	if err := ic.Provide("driver", dependency.New(newDriver, "main")); err != nil {
+		t.Fatal(err)
+	}
+
+	pipe := dependency.NewSingleton(func(db database) (*reader, *writer) {
+		return &reader{source: db.client()}, &writer{source: db.client()}
+	}, dependency.Inject("driver"))
+
+	if err := ic.Provide("r", pipe, WithOutputNames("w")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ic.Get("w"); err != nil {
+		t.Fatal(err)
+	}
+
+	factory := "func(container.database) (*container.reader, *container.writer)"
+
+	expected := Graph{
+		Nodes: []GraphNode{
+			{ID: "driver", Factory: "func(string) *container.driver"},
+			{ID: "r", Factory: factory, Singleton: true},
+			{ID: "w", Factory: factory, Singleton: true, Built: true},
+		},
+		Edges: []GraphEdge{
+			{From: "r", To: "driver", Argument: 0},
+			{From: "w", To: "r", Argument: 0},
+		},
+	}
+
+	assert.Equal(t, expected, ic.Graph())
+}
+
+func TestGraph_DOT(t *testing.T) {
+	expected := `digraph inject {
+	node [shape=box];
+	"driver" [label="driver\nfunc(string) *container.driver\nsingleton, built", style=bold];
+	"todo" [label="todo\nfunc(container.database) *container.todo"];
+	"todo/0" [label="todo/0\nfunc(string) *container.driver", style=dashed];
+	"user" [label="user\nfunc(container.database) *container.user"];
+	"todo" -> "todo/0" [label="0"];
+	"user" -> "driver" [label="0"];
+}
+`
+
+	assert.Equal(t, expected, newGraphContainer(t).Graph().DOT())
+}
+
+func TestGraph_Mermaid(t *testing.T) {
+	ic := newGraphContainer(t)
+
+	if err := ic.Provide("broken", dependency.New(newUserWithDriver, dependency.Inject("missing"))); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `graph TD
+	n0["broken<br/>func(container.database) *container.user"]
+	n1["driver<br/>func(string) *container.driver<br/>singleton, built"]
+	n2["todo<br/>func(container.database) *container.todo"]
+	n3["todo/0<br/>func(string) *container.driver"]
+	n4["user<br/>func(container.database) *container.user"]
+	n5["missing<br/>missing"]
+	n0 -->|0| n5
+	n2 -->|0| n3
+	n4 -->|0| n1
+`
+
+	assert.Equal(t, expected, ic.Graph().Mermaid())
+}
+
+func TestGraph_JSON(t *testing.T) {
+	out, err := newGraphContainer(t).Graph().JSON()
+
+	expected := `{
+  "nodes": [
+    {
+      "id": "driver",
+      "factory": "func(string) *container.driver",
+      "singleton": true,
+      "scoped": false,
+      "built": true,
+      "nested": false
+    },
+    {
+      "id": "todo",
+      "factory": "func(container.database) *container.todo",
+      "singleton": false,
+      "scoped": false,
+      "built": false,
+      "nested": false
+    },
+    {
+      "id": "todo/0",
+      "factory": "func(string) *container.driver",
+      "singleton": false,
+      "scoped": false,
+      "built": false,
+      "nested": true
+    },
+    {
+      "id": "user",
+      "factory": "func(container.database) *container.user",
+      "singleton": false,
+      "scoped": false,
+      "built": false,
+      "nested": false
+    }
+  ],
+  "edges": [
+    {
+      "from": "todo",
+      "to": "todo/0",
+      "argument": 0
+    },
+    {
+      "from": "user",
+      "to": "driver",
+      "argument": 0
+    }
+  ]
+}`
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, string(out))
+}
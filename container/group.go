@@ -0,0 +1,59 @@
+package container
+
+import (
+	"context"
+
+	"github.com/Drafteame/inject/types"
+)
+
+// GetGroup returns an instance of each member of the given value group, in the same order they were provided. A group
+// with no members is not an error, and returns an empty list.
+func (c *Container) GetGroup(name types.Symbol) ([]any, error) {
+	r := c.newResolver(context.Background())
+
+	vals, err := r.GetGroup(name)
+	if err != nil {
+		return nil, r.err(err)
+	}
+
+	return vals, nil
+}
+
+// GetGroup resolves every member of the given value group on the current build path.
+func (r resolver) GetGroup(name types.Symbol) ([]any, error) {
+	members := r.container.groupMembers(name)
+	vals := make([]any, 0, len(members))
+
+	for _, member := range members {
+		val, err := r.Get(member)
+		if err != nil {
+			return nil, err
+		}
+
+		vals = append(vals, val)
+	}
+
+	return vals, nil
+}
+
+// groupMembers returns the names of the dependencies that are members of the group, starting by the ones provided on
+// the root container and ending by the ones provided on this container.
+func (c *Container) groupMembers(name types.Symbol) []types.Symbol {
+	members := make([]types.Symbol, 0)
+	chain := c.chain()
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		scope := chain[i]
+		scope.mu.RLock()
+
+		for _, member := range scope.groups[name] {
+			if !containsSymbol(members, member) {
+				members = append(members, member)
+			}
+		}
+
+		scope.mu.RUnlock()
+	}
+
+	return members
+}
@@ -0,0 +1,152 @@
+package container
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Drafteame/inject/dependency"
+	"github.com/Drafteame/inject/types"
+)
+
+func TestContainer_GetGroup(t *testing.T) {
+	t.Run("get group members in registration order", func(t *testing.T) {
+		ic := New()
+
+		for _, name := range []string{"c", "a", "b"} {
+			if err := ic.Provide(types.Symbol(name), dependency.New(newUser, name, 21).InGroup("users")); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		if err := ic.ProvideType(dependency.New(newUser, "d", 21).InGroup("users")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		vals, err := ic.GetGroup("users")
+
+		if assert.NoError(t, err) && assert.Len(t, vals, 4) {
+			for i, name := range []string{"c", "a", "b", "d"} {
+				assert.Equal(t, name, vals[i].(*user).getName())
+			}
+		}
+	})
+
+	t.Run("get empty group", func(t *testing.T) {
+		ic := New()
+
+		vals, err := ic.GetGroup("users")
+
+		assert.NoError(t, err)
+		assert.Empty(t, vals)
+	})
+
+	t.Run("get group with member build error", func(t *testing.T) {
+		ic := New()
+
+		dep := dependency.New(func() (*user, error) { return nil, errors.New("some") }).InGroup("users")
+
+		if err := ic.Provide("user", dep); err != nil {
+			t.Error(err)
+			return
+		}
+
+		_, err := ic.GetGroup("users")
+
+		assert.EqualError(t, err, "inject: error building dependency instance: inject: error constructing `func() (*container.user, error)`: some")
+	})
+
+	t.Run("invoke with group field", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("john", dependency.NewSingleton(newUser, "John", 21).InGroup("namers")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("jane", dependency.New(newUser, "Jane", 22).InGroup("namers", "agers")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		type args struct {
+			types.In
+			Namers []namer `inject:"group=namers"`
+			Agers  []ager  `inject:"group=agers"`
+		}
+
+		called := false
+
+		err := ic.Invoke(func(in args) {
+			if assert.Len(t, in.Namers, 2) {
+				assert.Equal(t, "John", in.Namers[0].getName())
+				assert.Equal(t, "Jane", in.Namers[1].getName())
+			}
+
+			if assert.Len(t, in.Agers, 1) {
+				assert.Equal(t, 22, in.Agers[0].getAge())
+			}
+
+			called = true
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, called)
+	})
+
+	t.Run("invoke with incompatible group member", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("user", dependency.New(newUser, "John", 21).InGroup("databases")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		type args struct {
+			types.In
+			Databases []database `inject:"group=databases"`
+		}
+
+		err := ic.Invoke(func(in args) {})
+
+		expErr := errors.New("inject: using *container.user as type container.database on member 0 of group `databases` for field `Databases`")
+
+		assert.Error(t, err)
+		assert.Equal(t, expErr, err)
+	})
+
+	t.Run("invoke with non slice group field", func(t *testing.T) {
+		ic := New()
+
+		type args struct {
+			types.In
+			Database database `inject:"group=databases"`
+		}
+
+		err := ic.Invoke(func(in args) {})
+
+		expErr := errors.New("inject: group field `Database` should be a slice, got `container.database`")
+
+		assert.Error(t, err)
+		assert.Equal(t, expErr, err)
+	})
+
+	t.Run("invoke with name and group tags", func(t *testing.T) {
+		ic := New()
+
+		type args struct {
+			types.In
+			Databases []database `inject:"name=db,group=databases"`
+		}
+
+		err := ic.Invoke(func(in args) {})
+
+		expErr := errors.New("inject: name and group tags can't be used together on field `Databases`")
+
+		assert.Error(t, err)
+		assert.Equal(t, expErr, err)
+	})
+}
@@ -1,6 +1,7 @@
 package container
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 
@@ -13,24 +14,88 @@ import (
 // type will be ignored on resolution). When invoker is called it will resolve the dependency threes of each field from
 // the previously provided resources on Container.
 func (c *Container) Invoke(construct any) error {
+	return c.InvokeContext(context.Background(), construct)
+}
+
+// InvokeContext is the same as Invoke, but the dependency threes are resolved with the given context. The context is
+// also injected on every invoker parameter, or `types.In` field, of type context.Context.
+func (c *Container) InvokeContext(ctx context.Context, construct any) error {
+	ctype, res, err := c.invoke(ctx, construct)
+	if err != nil {
+		return err
+	}
+
+	return getResponseError(ctype, res)
+}
+
+// Call is the same as Invoke, but it also returns the primary result of the invoker, that is its first return value
+// that is not the error. The invoker should return at least one value besides the error.
+func (c *Container) Call(construct any) (any, error) {
+	return c.CallContext(context.Background(), construct)
+}
+
+// CallContext is the same as Call, but the dependency threes are resolved with the given context, in the same way as
+// InvokeContext does.
+func (c *Container) CallContext(ctx context.Context, construct any) (any, error) {
+	if construct != nil {
+		if _, ok := getResultIndex(reflect.TypeOf(construct)); !ok {
+			return nil, fmt.Errorf("inject: invoker `%v` should return a result besides the error", reflect.TypeOf(construct))
+		}
+	}
+
+	ctype, res, err := c.invoke(ctx, construct)
+	if err != nil {
+		return nil, err
+	}
+
+	index, _ := getResultIndex(ctype)
+
+	return res[index].Interface(), getResponseError(ctype, res)
+}
+
+// invoke resolves the parameters of the invoker on a new top level resolution and calls it, returning its type and
+// every value it returns.
+func (c *Container) invoke(ctx context.Context, construct any) (reflect.Type, []reflect.Value, error) {
 	if construct == nil {
-		return fmt.Errorf("inject: can't invoke nil constructor")
+		return nil, nil, fmt.Errorf("inject: can't invoke nil constructor")
+	}
+
+	if err := c.checkOpen(); err != nil {
+		return nil, nil, err
 	}
 
 	ctype := reflect.TypeOf(construct)
 
 	if ctype.Kind() != reflect.Func {
-		return fmt.Errorf("inject: can't invoke a non-function constructor")
+		return nil, nil, fmt.Errorf("inject: can't invoke a non-function constructor")
 	}
 
-	args, err := c.getInDeps(ctype)
+	r := c.newResolver(ctx)
+
+	args, err := r.getInDeps(ctype)
 	if err != nil {
-		return err
+		return nil, nil, r.err(err)
 	}
 
-	res := reflect.ValueOf(construct).Call(args)
+	return ctype, reflect.ValueOf(construct).Call(args), nil
+}
 
-	return getResponseError(ctype, res)
+// getResultIndex returns the index of the first return value of the function that is not the error found by
+// `utils.WhereErrorOut`, and false if there is none.
+func getResultIndex(ctype reflect.Type) (int, bool) {
+	if ctype.Kind() != reflect.Func {
+		return 0, false
+	}
+
+	errIndex, hasErr := utils.WhereErrorOut(ctype)
+
+	for i := 0; i < ctype.NumOut(); i++ {
+		if !hasErr || i != errIndex {
+			return i, true
+		}
+	}
+
+	return 0, false
 }
 
 // getResponseError It gets the type of the function that is being called. It checks if the function has an error as
@@ -50,17 +115,24 @@ func getResponseError(ctype reflect.Type, res []reflect.Value) error {
 	return *err.Interface().(*types.Error)
 }
 
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
 // getInDeps It creates a slice of reflect.Value with the size of the number of input parameters. For each input
 // parameter, it creates a new `reflect.Value` using `reflect.New`. Then it calls `buildInStruct` to build the struct
 // and set its fields. If the type or the input struct is not a pointer, we need to get its value using `Elem()` method.
 // We add this value to our slice of values and return it at the end.
-func (c *Container) getInDeps(ctype reflect.Type) ([]reflect.Value, error) {
+func (r resolver) getInDeps(ctype reflect.Type) ([]reflect.Value, error) {
 	values := make([]reflect.Value, ctype.NumIn())
 
 	for i := 0; i < ctype.NumIn(); i++ {
+		if ctype.In(i) == contextType {
+			values[i] = reflect.ValueOf(&r.ctx).Elem()
+			continue
+		}
+
 		newArg := reflect.New(ctype.In(i))
 
-		if err := types.BuildIn(c, newArg); err != nil {
+		if err := types.BuildIn(r, newArg); err != nil {
 			return nil, err
 		}
 
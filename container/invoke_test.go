@@ -1,6 +1,7 @@
 package container
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -148,10 +149,10 @@ func TestContainer_Invoke(t *testing.T) {
 		invoker := func(in args) {}
 
 		err := inject.Invoke(invoker)
-		expErr := errors.New("inject: missing name tag of inject dependency on field `UserService`")
+		expErr := errors.New("inject: no provided dependency of type `container.userer`")
 
 		assert.Error(t, err)
-		assert.Equal(t, expErr, err)
+		assert.EqualError(t, err, expErr.Error())
 	})
 
 	t.Run("invoke with nil invoker", func(t *testing.T) {
@@ -253,6 +254,56 @@ func TestContainer_Invoke(t *testing.T) {
 		assert.True(t, called)
 	})
 
+	t.Run("invoke with untagged field resolved by type", func(t *testing.T) {
+		inject := New()
+
+		if err := inject.ProvideType(dependency.New(newUser, "John", 21)); err != nil {
+			t.Error(err)
+			return
+		}
+
+		type args struct {
+			types.In
+			UserService *user
+		}
+
+		called := false
+
+		invoker := func(in args) {
+			if assert.NotNil(t, in.UserService) {
+				assert.Equal(t, "John", in.UserService.getName())
+			}
+
+			called = true
+		}
+
+		err := inject.Invoke(invoker)
+
+		assert.NoError(t, err)
+		assert.True(t, called)
+	})
+
+	t.Run("invoke with optional untagged field and no provider", func(t *testing.T) {
+		inject := New()
+
+		type args struct {
+			types.In
+			UserService *user `inject:"optional"`
+		}
+
+		called := false
+
+		invoker := func(in args) {
+			assert.Nil(t, in.UserService)
+			called = true
+		}
+
+		err := inject.Invoke(invoker)
+
+		assert.NoError(t, err)
+		assert.True(t, called)
+	})
+
 	t.Run("invoke with error providing field with no tag", func(t *testing.T) {
 		inject := New()
 
@@ -269,10 +320,10 @@ func TestContainer_Invoke(t *testing.T) {
 
 		err := inject.Invoke(invoker)
 
-		expErr := fmt.Errorf("inject: missing name tag of inject dependency on field `UserService`")
+		expErr := fmt.Errorf("inject: no provided dependency of type `container.some`")
 
 		assert.Error(t, err)
-		assert.Equal(t, expErr, err)
+		assert.EqualError(t, err, expErr.Error())
 	})
 
 	t.Run("invoke with error providing named dependency", func(t *testing.T) {
@@ -290,7 +341,7 @@ func TestContainer_Invoke(t *testing.T) {
 		expErr := fmt.Errorf("inject: no provided dependency of name `usersService`")
 
 		assert.Error(t, err)
-		assert.Equal(t, expErr, err)
+		assert.EqualError(t, err, expErr.Error())
 	})
 
 	t.Run("invoke error resolving dependency three", func(t *testing.T) {
@@ -315,7 +366,7 @@ func TestContainer_Invoke(t *testing.T) {
 		expErr := fmt.Errorf("inject: error building dependency instance: inject: error constructing `func() (*container.user, error)`: some")
 
 		assert.Error(t, err)
-		assert.Equal(t, expErr, err)
+		assert.EqualError(t, err, expErr.Error())
 	})
 
 	t.Run("invoke with shared dependency on multiple targets as singleton", func(t *testing.T) {
@@ -397,7 +448,7 @@ func TestContainer_Invoke(t *testing.T) {
 		expErr := errors.New("inject: error building dependency instance: inject: error resolving argument 0 for constructor func(container.database) *container.user: inject: error building dependency instance: inject: error constructing `func(string) (*container.driver, error)`: some")
 
 		assert.Error(t, err)
-		assert.Equal(t, expErr, err)
+		assert.EqualError(t, err, expErr.Error())
 	})
 
 	t.Run("invoke with shared dependency that does not exist", func(t *testing.T) {
@@ -431,6 +482,368 @@ func TestContainer_Invoke(t *testing.T) {
 		expErr := errors.New("inject: error building dependency instance: inject: error resolving argument 0 for constructor func(container.database) *container.user: inject: no provided dependency of name `algo`")
 
 		assert.Error(t, err)
-		assert.Equal(t, expErr, err)
+		assert.EqualError(t, err, expErr.Error())
+	})
+}
+
+func TestContainer_InvokeContext(t *testing.T) {
+	type ctxKey struct{}
+
+	t.Run("inject context on parameters and fields", func(t *testing.T) {
+		ic := New()
+		ctx := context.WithValue(context.Background(), ctxKey{}, "main")
+
+		if err := ic.Provide("driver", dependency.New(func(ctx context.Context) *driver {
+			return newDriver(ctx.Value(ctxKey{}).(string))
+		})); err != nil {
+			t.Error(err)
+			return
+		}
+
+		type args struct {
+			types.In
+			Ctx    context.Context
+			Driver *driver `inject:"name=driver"`
+		}
+
+		var paramCtx, fieldCtx context.Context
+		var dbName string
+
+		err := ic.InvokeContext(ctx, func(ctx context.Context, in args) {
+			paramCtx = ctx
+			fieldCtx = in.Ctx
+			dbName = in.Driver.client()
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, ctx, paramCtx)
+		assert.Equal(t, ctx, fieldCtx)
+		assert.Equal(t, "main", dbName)
+	})
+
+	t.Run("stop invoke on canceled context", func(t *testing.T) {
+		ic := New()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if err := ic.Provide("driver", dependency.New(newDriver, "main")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		type args struct {
+			types.In
+			Driver *driver `inject:"name=driver"`
+		}
+
+		called := false
+
+		err := ic.InvokeContext(ctx, func(in args) { called = true })
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.False(t, called)
+	})
+}
+
+func TestContainer_InvokeLazy(t *testing.T) {
+	t.Run("resolve lazy fields when called", func(t *testing.T) {
+		ic := New()
+		builds := 0
+
+		if err := ic.Provide("user", dependency.New(func() *user {
+			builds++
+			return newUser("John", 21)
+		})); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.ProvideType(dependency.NewSingleton(newDriver, "main")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		type args struct {
+			types.In
+			User   types.Lazy[*user] `inject:"name=user"`
+			Driver func() (*driver, error)
+		}
+
+		var in args
+
+		err := ic.Invoke(func(a args) { in = a })
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, builds)
+
+		u1, err := in.User()
+		assert.NoError(t, err)
+
+		u2, err := in.User()
+		assert.NoError(t, err)
+
+		assert.Equal(t, 2, builds)
+		assert.NotSame(t, u1, u2)
+
+		d, err := in.Driver()
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, "main", d.client())
+		}
+	})
+
+	t.Run("lazy dependencies can reference each other", func(t *testing.T) {
+		ic := New()
+
+		type node struct {
+			next types.Lazy[*user]
+		}
+
+		if err := ic.Provide("a", dependency.NewSingleton(func(next types.Lazy[*user]) *node {
+			return &node{next: next}
+		}, dependency.Inject("b"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("b", dependency.New(func(n *node) *user {
+			return newUser("John", 21)
+		}, dependency.Inject("a"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		n, err := ic.Get("a")
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		u, err := n.(*node).next()
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, "John", u.getName())
+		}
+	})
+
+	t.Run("lazy dependency not found when called", func(t *testing.T) {
+		ic := New()
+
+		type args struct {
+			types.In
+			User types.Lazy[*user] `inject:"name=user"`
+		}
+
+		var in args
+
+		err := ic.Invoke(func(a args) { in = a })
+
+		assert.NoError(t, err)
+
+		_, err = in.User()
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+	})
+
+	t.Run("inject function dependencies as they are", func(t *testing.T) {
+		ic := New()
+
+		fn := func() (string, error) { return "value", nil }
+
+		if err := ic.Provide("fn", dependency.New(func() func() (string, error) { return fn })); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("caller", dependency.New(func(fn func() (string, error)) (string, error) {
+			return fn()
+		}, dependency.Inject("fn"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		type args struct {
+			types.In
+			Fn     func() (string, error) `inject:"name=fn"`
+			Caller string                 `inject:"name=caller"`
+		}
+
+		err := ic.Invoke(func(a args) {
+			val, err := a.Fn()
+
+			assert.NoError(t, err)
+			assert.Equal(t, "value", val)
+			assert.Equal(t, "value", a.Caller)
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, ic.Validate())
+	})
+}
+
+type storageDeps struct {
+	types.In
+	DB *driver `inject:"name=db"`
+}
+
+type handlerDeps struct {
+	types.In
+	Storage storageDeps
+	Cache   *storageDeps
+}
+
+func TestContainer_InvokeUnexported(t *testing.T) {
+	t.Run("skip untagged unexported fields", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("db", dependency.New(newDriver, "main")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		type args struct {
+			types.In
+			db *driver
+			DB *driver `inject:"name=db"`
+		}
+
+		err := ic.Invoke(func(in args) {
+			assert.Nil(t, in.db)
+			assert.NotNil(t, in.DB)
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error on tagged unexported fields", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("db", dependency.New(newDriver, "main")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		type args struct {
+			types.In
+			db *driver `inject:"name=db"`
+		}
+
+		err := ic.Invoke(func(in args) {})
+
+		assert.ErrorContains(t, err, "inject: can't set unexported field `db`")
+	})
+}
+
+func TestContainer_InvokeNested(t *testing.T) {
+	t.Run("fill nested and embedded In structs", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("db", dependency.New(newDriver, "main")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		type args struct {
+			types.In
+			handlerDeps
+			Name string `inject:"name=name,optional"`
+		}
+
+		err := ic.Invoke(func(in args) {
+			assert.Equal(t, "main", in.Storage.DB.client())
+
+			if assert.NotNil(t, in.Cache) {
+				assert.Equal(t, "main", in.Cache.DB.client())
+			}
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error with the full field path", func(t *testing.T) {
+		ic := New()
+
+		type args struct {
+			types.In
+			Deps handlerDeps
+		}
+
+		err := ic.Invoke(func(in args) {})
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+		assert.ErrorContains(t, err, "inject: error filling field `Deps.Storage.DB`")
+	})
+
+	t.Run("In struct nested on itself", func(t *testing.T) {
+		type node struct {
+			types.In
+			Next *node
+		}
+
+		ic := New()
+
+		err := ic.Invoke(func(in node) {})
+
+		assert.ErrorContains(t, err, "is nested on itself on field `Next`")
+	})
+}
+
+func TestContainer_Call(t *testing.T) {
+	t.Run("return primary result", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("driver", dependency.New(newDriver, "main")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		type args struct {
+			types.In
+			Driver *driver `inject:"name=driver"`
+		}
+
+		res, err := ic.Call(func(in args) (string, error) {
+			return in.Driver.client(), nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "main", res)
+	})
+
+	t.Run("return result along with the invoker error", func(t *testing.T) {
+		ic := New()
+
+		res, err := ic.Call(func() (error, int) {
+			return assert.AnError, 1
+		})
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 1, res)
+	})
+
+	t.Run("invoker with no result", func(t *testing.T) {
+		ic := New()
+		called := false
+
+		_, err := ic.Call(func() error {
+			called = true
+			return nil
+		})
+
+		assert.EqualError(t, err, "inject: invoker `func() error` should return a result besides the error")
+		assert.False(t, called)
+	})
+
+	t.Run("error resolving invoker parameters", func(t *testing.T) {
+		ic := New()
+
+		type args struct {
+			types.In
+			Driver *driver `inject:"name=driver"`
+		}
+
+		res, err := ic.Call(func(in args) *driver { return in.Driver })
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+		assert.Nil(t, res)
 	})
 }
@@ -0,0 +1,257 @@
+package container
+
+import (
+	"fmt"
+
+	"github.com/Drafteame/inject/dependency"
+	"github.com/Drafteame/inject/types"
+	"github.com/Drafteame/inject/utils"
+)
+
+// Module is a set of dependencies that is installed on a container as a unit. Each dependency is provided with the
+// module name as a prefix of its name, e.g. the dependency `client` of the module `payments` is provided as
+// `payments.client`.
+type Module struct {
+	name          string
+	registrations []Registration
+}
+
+// Registration is a dependency registered by a module.
+type Registration struct {
+	name    types.Symbol
+	dep     dependency.Dependency
+	private bool
+}
+
+// membership records the module that registered a dependency, and if the dependency is private to it.
+type membership struct {
+	module  string
+	private bool
+}
+
+// NewModule creates a module with the given name and registrations. Injected names of the registrations that reference
+// other dependency of the same module are prefixed with the module name too, so the dependencies of a module can
+// reference each other by their short name.
+func NewModule(name string, registrations ...Registration) Module {
+	return Module{name: name, registrations: registrations}
+}
+
+// Register creates a registration of a public dependency, that can be resolved from anywhere with the module prefix.
+func Register(name types.Symbol, dep dependency.Dependency, opts ...ProvideOption) Registration {
+	return Registration{name: name, dep: applyOptions(dep, opts)}
+}
+
+// Private creates a registration of a dependency that can only be injected on other dependencies of the same module.
+// Private dependencies are not resolvable by type nor members of value groups.
+func Private(name types.Symbol, dep dependency.Dependency, opts ...ProvideOption) Registration {
+	return Registration{name: name, dep: applyOptions(dep, opts), private: true}
+}
+
+// Name returns the name of the module.
+func (m Module) Name() string {
+	return m.name
+}
+
+// Symbol returns the name a dependency of the module is provided with.
+func (m Module) Symbol(name types.Symbol) types.Symbol {
+	return types.Symbol(m.name + "." + string(name))
+}
+
+// Install provides every dependency of the module on the container. The module is installed as a unit, so if any of its
+// dependencies can't be provided, none of them is. Errors are returned as a types.ModuleError that names the module.
+func (c *Container) Install(m Module) error {
+	if m.name == "" {
+		return fmt.Errorf("inject: module name cannot be empty")
+	}
+
+	provisions, err := m.provisions()
+	if err != nil {
+		return &types.ModuleError{Module: m.name, Err: err}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return types.ErrClosed
+	}
+
+	if err := c.checkProvisions(provisions); err != nil {
+		return &types.ModuleError{Module: m.name, Err: err}
+	}
+
+	if c.deps == nil {
+		c.deps = make(map[types.Symbol]dependency.Dependency)
+	}
+
+	if c.members == nil {
+		c.members = make(map[types.Symbol]membership)
+	}
+
+	for _, p := range provisions {
+		c.deps[p.name] = p.dep
+
+		member := membership{module: m.name, private: p.private}
+		c.members[p.name] = member
+
+		for _, name := range c.index(p) {
+			c.members[name] = member
+		}
+	}
+
+	return nil
+}
+
+// provisions checks every registration of the module and returns them ready to be provided, with the module prefix on
+// their names and on the names of the outputs they declare.
+func (m Module) provisions() ([]provision, error) {
+	locals := m.locals()
+	provisions := make([]provision, 0, len(m.registrations))
+
+	for _, reg := range m.registrations {
+		if reg.name == "" {
+			return nil, fmt.Errorf("inject: dependency name cannot be empty")
+		}
+
+		p, err := newProvision(m.Symbol(reg.name), m.qualify(reg.dep, locals))
+		if err != nil {
+			return nil, fmt.Errorf("inject: invalid dependency `%s`: %w", reg.name, err)
+		}
+
+		for i, out := range p.outputs {
+			if !out.internal && out.name != "" {
+				p.outputs[i].name = m.Symbol(out.name)
+			}
+		}
+
+		p.private = reg.private
+		provisions = append(provisions, p)
+	}
+
+	return provisions, nil
+}
+
+// locals returns the short names of every dependency registered by the module, including the outputs they declare.
+func (m Module) locals() []types.Symbol {
+	locals := make([]types.Symbol, 0, len(m.registrations))
+
+	for _, reg := range m.registrations {
+		locals = append(locals, reg.name)
+		locals = append(locals, reg.dep.OutputNames...)
+
+		rt := utils.GetFirstReturnType(reg.dep.Factory)
+		if !types.IsOut(rt) {
+			continue
+		}
+
+		fields, err := types.OutFields(rt)
+		if err != nil {
+			continue
+		}
+
+		for _, field := range fields {
+			locals = append(locals, field.Name)
+		}
+	}
+
+	return locals
+}
+
+// qualify returns a copy of the dependency where every injected name that references a dependency of the module has
+// the module prefix. Injected names of other dependencies are kept as they are.
+func (m Module) qualify(dep dependency.Dependency, locals []types.Symbol) dependency.Dependency {
+	args := make([]any, len(dep.Args))
+
+	for i, arg := range dep.Args {
+		switch a := arg.(type) {
+		case dependency.Injectable:
+			if containsSymbol(locals, a.Name()) {
+				arg = a.WithName(m.Symbol(a.Name()))
+			}
+		case dependency.Dependency:
+			arg = m.qualify(a, locals)
+		}
+
+		args[i] = arg
+	}
+
+	dep.Args = args
+
+	return dep
+}
+
+// checkProvisions returns a types.DuplicateError if any name of the provisions, or of the outputs they declare, is
+// repeated or already provided on the container. It should be called holding the container lock.
+func (c *Container) checkProvisions(provisions []provision) error {
+	names := make([]types.Symbol, 0, len(provisions))
+
+	for _, p := range provisions {
+		symbols := []types.Symbol{p.name}
+
+		for _, out := range p.outputs {
+			if out.name != "" {
+				symbols = append(symbols, out.name)
+			}
+		}
+
+		for _, name := range symbols {
+			if containsSymbol(names, name) {
+				return &types.DuplicateError{Name: name}
+			}
+
+			if _, ok := c.deps[name]; ok {
+				return c.duplicated(name)
+			}
+
+			names = append(names, name)
+		}
+	}
+
+	return nil
+}
+
+// duplicated returns the error for a name that is already provided on the container, naming the module that provided
+// it if any. It should be called holding the container lock.
+func (c *Container) duplicated(name types.Symbol) error {
+	err := &types.DuplicateError{Name: name}
+
+	if member, ok := c.members[name]; ok {
+		return fmt.Errorf("%w, provided by module `%s`", err, member.module)
+	}
+
+	return err
+}
+
+// membershipOf returns the module membership of the dependency of the given name, as registered on the closest
+// container of the chain that provides it.
+func (c *Container) membershipOf(name types.Symbol) (membership, bool) {
+	for _, scope := range c.chain() {
+		scope.mu.RLock()
+		_, provided := scope.deps[name]
+		member, ok := scope.members[name]
+		scope.mu.RUnlock()
+
+		if provided {
+			return member, ok
+		}
+	}
+
+	return membership{}, false
+}
+
+// checkAccess returns an error if the dependency is private to a module and `requester` is not a dependency of the
+// same module. An empty requester means that the dependency is requested from outside of any dependency three.
+func (c *Container) checkAccess(name, requester types.Symbol) error {
+	member, ok := c.membershipOf(name)
+	if !ok || !member.private {
+		return nil
+	}
+
+	if requester != "" {
+		if other, ok := c.membershipOf(requester); ok && other.module == member.module {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("inject: dependency `%s` is private to module `%s`: %w", name, member.module, types.ErrNotFound)
+}
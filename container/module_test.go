@@ -0,0 +1,219 @@
+package container
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Drafteame/inject/dependency"
+	"github.com/Drafteame/inject/types"
+)
+
+func TestContainer_Install(t *testing.T) {
+	t.Run("namespace module dependencies", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("config.db", dependency.New(func() string { return "main" })); err != nil {
+			t.Error(err)
+			return
+		}
+
+		users := NewModule("users",
+			Register("driver", dependency.NewSingleton(newDriver, dependency.Inject("config.db"))),
+			Register("service", dependency.New(newUserWithDriver, dependency.Inject("driver"))),
+		)
+
+		if err := ic.Install(users); err != nil {
+			t.Error(err)
+			return
+		}
+
+		val, err := ic.Get("users.service")
+
+		if assert.NoError(t, err) && assert.IsType(t, &user{}, val) {
+			assert.Equal(t, "main", val.(*user).getDb().client())
+		}
+
+		_, err = ic.Get("service")
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+		assert.Equal(t, types.Symbol("users.service"), users.Symbol("service"))
+	})
+
+	t.Run("private dependencies", func(t *testing.T) {
+		ic := New()
+
+		users := NewModule("users",
+			Private("driver", dependency.NewSingleton(newDriver, "main")),
+			Register("service", dependency.New(newUserWithDriver, dependency.Inject("driver"))),
+		)
+
+		if err := ic.Install(users); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("outsider", dependency.New(newUserWithDriver, dependency.Inject("users.driver"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		_, err := ic.Get("users.service")
+
+		assert.NoError(t, err)
+
+		_, err = ic.Get("users.driver")
+
+		assert.EqualError(t, err, "inject: dependency `users.driver` is private to module `users`: inject: dependency not found")
+		assert.ErrorIs(t, err, types.ErrNotFound)
+
+		_, err = ic.Get("outsider")
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+
+		_, err = ic.Resolve(reflect.TypeOf(&driver{}))
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+
+		err = ic.Validate()
+
+		assert.EqualError(t, err, "inject: invalid dependency `outsider`: inject: dependency `users.driver` is private to module `users`: inject: dependency not found")
+	})
+
+	t.Run("lazy private dependencies", func(t *testing.T) {
+		ic := New()
+
+		pay := NewModule("pay",
+			Private("secret", dependency.New(func() string { return "s3cr3t" })),
+			Register("client", dependency.New(func(secret types.Lazy[string]) types.Lazy[string] {
+				return secret
+			}, dependency.Inject("secret"))),
+		)
+
+		if err := ic.Install(pay); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("outsider", dependency.New(func(secret types.Lazy[string]) types.Lazy[string] {
+			return secret
+		}, dependency.Inject("pay.secret"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		val, err := ic.Get("pay.client")
+
+		if assert.NoError(t, err) {
+			secret, err := val.(types.Lazy[string])()
+
+			assert.NoError(t, err)
+			assert.Equal(t, "s3cr3t", secret)
+		}
+
+		val, err = ic.Get("outsider")
+
+		if assert.NoError(t, err) {
+			_, err := val.(types.Lazy[string])()
+
+			assert.ErrorIs(t, err, types.ErrNotFound)
+		}
+	})
+
+	t.Run("keep key types of module dependencies", func(t *testing.T) {
+		ic := New()
+
+		users := NewModule("users",
+			Register("driver", dependency.New(newDriver, "main")),
+			Register("service", dependency.New(newUserWithDriver, dependency.InjectKey(types.NewKey[prefixed]("driver")))),
+		)
+
+		if err := ic.Install(users); err != nil {
+			t.Error(err)
+			return
+		}
+
+		err := ic.Validate()
+
+		assert.EqualError(t, err, "inject: invalid dependency `users.service`: inject: error resolving argument 0 for constructor func(container.database) *container.user: inject: dependency `users.driver` of type *container.driver can't be used as key of type container.prefixed")
+	})
+
+	t.Run("namespace multi-output dependencies", func(t *testing.T) {
+		ic := New()
+
+		files := NewModule("files",
+			Private("pipe", dependency.New(func() (*reader, *writer) {
+				return &reader{source: "pipe"}, &writer{source: "pipe"}
+			}), WithOutputNames("writer")),
+			Register("service", dependency.New(func(w *writer) string { return w.source }, dependency.Inject("writer"))),
+		)
+
+		if err := ic.Install(files); err != nil {
+			t.Error(err)
+			return
+		}
+
+		val, err := ic.Get("files.service")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "pipe", val)
+
+		_, err = ic.Get("files.writer")
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+	})
+
+	t.Run("conflict with another module", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Install(NewModule("users", Register("service", dependency.New(newUser, "John", 21)))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		err := ic.Install(NewModule("users",
+			Register("driver", dependency.New(newDriver, "main")),
+			Register("service", dependency.New(newUser, "Jane", 22)),
+		))
+
+		assert.EqualError(t, err, "inject: error installing module `users`: inject: duplicated dependency name `users.service`, provided by module `users`")
+		assert.ErrorIs(t, err, types.ErrDuplicate)
+
+		var moduleErr *types.ModuleError
+
+		if assert.True(t, errors.As(err, &moduleErr)) {
+			assert.Equal(t, "users", moduleErr.Module)
+		}
+
+		_, ok := ic.Lookup("users.driver")
+
+		assert.False(t, ok)
+	})
+
+	t.Run("duplicated name on the same module", func(t *testing.T) {
+		ic := New()
+
+		err := ic.Install(NewModule("users",
+			Register("service", dependency.New(newUser, "John", 21)),
+			Private("service", dependency.New(newUser, "Jane", 22)),
+		))
+
+		assert.EqualError(t, err, "inject: error installing module `users`: inject: duplicated dependency name `users.service`")
+	})
+
+	t.Run("invalid registration", func(t *testing.T) {
+		ic := New()
+
+		err := ic.Install(NewModule("users", Register("service", dependency.New(func() {}))))
+
+		assert.EqualError(t, err, "inject: error installing module `users`: inject: invalid dependency `service`: inject: dependency factory should return at least one return type: dependency.Dependency{Factory: func(), Args: []}")
+	})
+
+	t.Run("empty module name", func(t *testing.T) {
+		err := New().Install(NewModule(""))
+
+		assert.EqualError(t, err, "inject: module name cannot be empty")
+	})
+}
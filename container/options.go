@@ -0,0 +1,75 @@
+package container
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Drafteame/inject/dependency"
+	"github.com/Drafteame/inject/types"
+)
+
+// ProvideOption configures a dependency when it is provided to the container.
+type ProvideOption func(dep *dependency.Dependency)
+
+// WithSingleton marks the dependency as a singleton, so it is built only once and shared.
+func WithSingleton() ProvideOption {
+	return func(dep *dependency.Dependency) {
+		dep.Singleton = true
+	}
+}
+
+// WithAs binds the dependency to the interfaces pointed by the provided values, in the same way as
+// `dependency.Dependency.As` does.
+func WithAs(ifaces ...any) ProvideOption {
+	return func(dep *dependency.Dependency) {
+		*dep = dep.As(ifaces...)
+	}
+}
+
+// WithGroup adds the dependency to the provided value groups, in the same way as `dependency.Dependency.InGroup` does.
+func WithGroup(groups ...types.Symbol) ProvideOption {
+	return func(dep *dependency.Dependency) {
+		*dep = dep.InGroup(groups...)
+	}
+}
+
+// WithOnClose sets the function that releases the built instances of the dependency when the container is closed,
+// instead of their own `Close` method. The instance is received as the type `T`.
+func WithOnClose[T any](fn func(ctx context.Context, instance T) error) ProvideOption {
+	return func(dep *dependency.Dependency) {
+		dep.OnClose = func(ctx context.Context, instance any) error {
+			cast, ok := instance.(T)
+			if !ok && instance != nil {
+				return fmt.Errorf("inject: can't close instance of type `%T` as `%T`", instance, cast)
+			}
+
+			return fn(ctx, cast)
+		}
+	}
+}
+
+// WithDescription sets a human readable description of the dependency, that is shown on the dependency graph.
+func WithDescription(description string) ProvideOption {
+	return func(dep *dependency.Dependency) {
+		dep.Description = description
+	}
+}
+
+// WithOutputNames names the outputs of a multi-output factory after the first one, in order. The first output takes
+// the name of the dependency, and outputs left without a name are registered by their type.
+func WithOutputNames(names ...types.Symbol) ProvideOption {
+	return func(dep *dependency.Dependency) {
+		dep.OutputNames = append(dep.OutputNames, names...)
+	}
+}
+
+// applyOptions returns a copy of the dependency with every option applied in order.
+func applyOptions(dep dependency.Dependency, opts []ProvideOption) dependency.Dependency {
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&dep)
+		}
+	}
+
+	return dep
+}
@@ -0,0 +1,107 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Drafteame/inject/dependency"
+)
+
+func TestContainer_ProvideOptions(t *testing.T) {
+	t.Run("singleton", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("user", dependency.New(newUser, "John", 21), WithSingleton()); err != nil {
+			t.Error(err)
+			return
+		}
+
+		u1, _ := ic.Get("user")
+		u2, _ := ic.Get("user")
+
+		assert.Same(t, u1, u2)
+	})
+
+	t.Run("interfaces and groups", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.ProvideType(dependency.New(newDriver, "main"), WithAs(new(database)), WithGroup("drivers")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		db, err := ic.Resolve(reflect.TypeOf((*database)(nil)).Elem())
+
+		assert.NoError(t, err)
+		assert.IsType(t, &driver{}, db)
+
+		members, err := ic.GetGroup("drivers")
+
+		assert.NoError(t, err)
+		assert.Len(t, members, 1)
+	})
+
+	t.Run("description", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("driver", dependency.New(newDriver, "main"), WithDescription("main database")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		g := ic.Graph()
+
+		assert.Equal(t, "main database", g.Nodes[0].Description)
+		assert.Contains(t, g.DOT(), `tooltip="main database"`)
+	})
+
+	t.Run("on close", func(t *testing.T) {
+		ic := New()
+		closed := make([]string, 0)
+		errClose := errors.New("close error")
+
+		onClose := WithOnClose(func(ctx context.Context, d *driver) error {
+			closed = append(closed, d.client())
+			return errClose
+		})
+
+		if err := ic.Provide("driver", dependency.NewSingleton(newDriver, "main"), onClose); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if _, err := ic.Get("driver"); err != nil {
+			t.Error(err)
+			return
+		}
+
+		err := ic.Close(context.Background())
+
+		assert.ErrorIs(t, err, errClose)
+		assert.Equal(t, []string{"main"}, closed)
+	})
+
+	t.Run("on close with wrong type", func(t *testing.T) {
+		ic := New()
+
+		onClose := WithOnClose(func(ctx context.Context, u *user) error { return nil })
+
+		if err := ic.Provide("driver", dependency.NewSingleton(newDriver, "main"), onClose); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if _, err := ic.Get("driver"); err != nil {
+			t.Error(err)
+			return
+		}
+
+		err := ic.Close(context.Background())
+
+		assert.EqualError(t, err, "inject: error closing `driver`: inject: can't close instance of type `*container.driver` as `*container.user`")
+	})
+}
@@ -0,0 +1,318 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Drafteame/inject/dependency"
+	"github.com/Drafteame/inject/types"
+	"github.com/Drafteame/inject/utils"
+)
+
+// output is a dependency that is registered along with another one, and that reads its value from the instance built
+// by it. Outputs with no name are registered by their type, and internal outputs have a name derived from the
+// dependency that declares them, and keep the type of the multi-output factory they call.
+type output struct {
+	name     types.Symbol
+	rtype    reflect.Type
+	dep      dependency.Dependency
+	internal bool
+	factory  reflect.Type
+}
+
+// results is the instance of a multi-output factory, that holds every value it returns except the error.
+type results []any
+
+var (
+	resultsType = reflect.TypeOf(results{})
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// sharedKey identifies a dependency that is built just once on each top level resolution.
+type sharedKey struct {
+	container *Container
+	name      types.Symbol
+}
+
+// getOutputs returns a dependency for each named field of the struct returned by the factory, if it embeds the
+// `types.Out` struct. Each of them injects the dependency of the given name and reads its field, so all of them are
+// backed by the same build.
+func getOutputs(name types.Symbol, dep dependency.Dependency) ([]output, error) {
+	rt := utils.GetFirstReturnType(dep.Factory)
+
+	if !types.IsOut(rt) {
+		return nil, nil
+	}
+
+	fields, err := types.OutFields(rt)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]output, 0, len(fields))
+
+	for _, field := range fields {
+		outputs = append(outputs, output{
+			name:  field.Name,
+			rtype: field.Type,
+			dep:   outputDependency(dep, fieldGetter(rt, field), name),
+		})
+	}
+
+	return outputs, nil
+}
+
+// splitResults returns the dependencies of a multi-output factory. The factory itself is registered as an internal
+// output that builds every result at once, and the dependency provided with the given name, along with an output for
+// each of the rest of the results, reads its value from it. Extra outputs are named by `OutputNames`, in order, and
+// the ones without a name are registered by their type.
+func splitResults(name types.Symbol, dep dependency.Dependency, rts []reflect.Type) (dependency.Dependency, []output, error) {
+	ftype := reflect.TypeOf(dep.Factory)
+
+	for _, rt := range rts {
+		if rt == errorType {
+			return dep, nil, fmt.Errorf("inject: factory `%v` can only return an error as its last result", ftype)
+		}
+	}
+
+	if len(dep.OutputNames) > len(rts)-1 {
+		return dep, nil, fmt.Errorf("inject: factory `%v` has %d extra outputs, got %d output names", ftype, len(rts)-1, len(dep.OutputNames))
+	}
+
+	source := sourceName(name)
+
+	outputs := []output{{
+		name:     source,
+		internal: true,
+		factory:  ftype,
+		dep: dependency.Dependency{
+			Factory:   resultsFactory(dep.Factory),
+			Args:      dep.Args,
+			Singleton: dep.Singleton,
+			Scoped:    dep.Scoped,
+		},
+	}}
+
+	for i := 1; i < len(rts); i++ {
+		out := output{rtype: rts[i], dep: outputDependency(dep, resultGetter(i, rts[i]), source)}
+
+		if i <= len(dep.OutputNames) {
+			out.name = dep.OutputNames[i-1]
+		}
+
+		outputs = append(outputs, out)
+	}
+
+	first := dep
+	first.Factory = resultGetter(0, rts[0])
+	first.Args = []any{dependency.Inject(source)}
+	first.OutputNames = nil
+
+	return first, outputs, nil
+}
+
+// sourceName returns the name of the internal output that builds every result of the multi-output factory provided
+// with the given name.
+func sourceName(name types.Symbol) types.Symbol {
+	return types.Symbol(fmt.Sprintf("%s#outputs", name))
+}
+
+// declarerOf returns the name of the dependency that declares the internal output of the given name.
+func declarerOf(source types.Symbol) types.Symbol {
+	return types.Symbol(strings.TrimSuffix(string(source), "#outputs"))
+}
+
+// sourceFactory returns the type of the multi-output factory called by the internal output of the given name, on this
+// container or any of its parents, and false if the name is not an internal output.
+func (c *Container) sourceFactory(name types.Symbol) (reflect.Type, bool) {
+	for _, scope := range c.chain() {
+		scope.mu.RLock()
+		ftype, ok := scope.sources[name]
+		scope.mu.RUnlock()
+
+		if ok {
+			return ftype, true
+		}
+	}
+
+	return nil, false
+}
+
+// declaresOutputs returns true if the dependency provided with the given name declares outputs. It should be called
+// holding the container lock.
+func (c *Container) declaresOutputs(name types.Symbol) bool {
+	if _, ok := c.deps[sourceName(name)]; ok {
+		return true
+	}
+
+	return types.IsOut(utils.GetFirstReturnType(c.deps[name].Factory))
+}
+
+// outputDependency returns the dependency of an output, that injects the source dependency into the getter and has
+// its same lifetime.
+func outputDependency(source dependency.Dependency, getter any, name types.Symbol) dependency.Dependency {
+	return dependency.Dependency{
+		Factory:   getter,
+		Args:      []any{dependency.Inject(name)},
+		Singleton: source.Singleton,
+		Scoped:    source.Scoped,
+	}
+}
+
+// checkOutputs returns a types.DuplicateError if any of the outputs has the name of an already provided dependency, of
+// the dependency that declares them, or of another output. It should be called holding the container lock.
+func (c *Container) checkOutputs(name types.Symbol, outputs []output) error {
+	names := []types.Symbol{name}
+
+	for _, out := range outputs {
+		if out.name == "" {
+			continue
+		}
+
+		if _, ok := c.deps[out.name]; ok || containsSymbol(names, out.name) {
+			return &types.DuplicateError{Name: out.name}
+		}
+
+		names = append(names, out.name)
+	}
+
+	return nil
+}
+
+// provideOutputs registers every output on the container, returning the names they were registered with. If `indexed`
+// is true, they are also indexed by their type. It should be called holding the container lock, after checking them
+// with checkOutputs.
+func (c *Container) provideOutputs(outputs []output, indexed bool) []types.Symbol {
+	names := make([]types.Symbol, 0, len(outputs))
+
+	for _, out := range outputs {
+		name := out.name
+
+		if name == "" {
+			name = c.typeSymbol(out.rtype)
+		}
+
+		c.deps[name] = out.dep
+		names = append(names, name)
+
+		if out.internal {
+			if c.sources == nil {
+				c.sources = make(map[types.Symbol]reflect.Type)
+			}
+
+			c.sources[name] = out.factory
+		}
+
+		if indexed && out.rtype != nil {
+			c.indexType([]reflect.Type{out.rtype}, name)
+		}
+	}
+
+	return names
+}
+
+// isShared returns true if the dependency declares outputs, so it should be built just once on each top level
+// resolution even if it is not a singleton.
+func isShared(dep dependency.Dependency) bool {
+	rt := utils.GetFirstReturnType(dep.Factory)
+	return rt == resultsType || types.IsOut(rt)
+}
+
+// resultsFactory creates a function with the same parameters of the multi-output factory, that calls it and returns
+// every result except the error as a `results` value, along with the error.
+func resultsFactory(factory any) any {
+	fn := reflect.ValueOf(factory)
+	ftype := fn.Type()
+
+	in := make([]reflect.Type, ftype.NumIn())
+
+	for i := range in {
+		in[i] = ftype.In(i)
+	}
+
+	rtype := reflect.FuncOf(in, []reflect.Type{resultsType, errorType}, ftype.IsVariadic())
+
+	return reflect.MakeFunc(rtype, func(args []reflect.Value) []reflect.Value {
+		var res []reflect.Value
+
+		if ftype.IsVariadic() {
+			res = fn.CallSlice(args)
+		} else {
+			res = fn.Call(args)
+		}
+
+		vals := make(results, 0, len(res))
+		err := reflect.Zero(errorType)
+
+		for i, r := range res {
+			if i == len(res)-1 && ftype.Out(i) == errorType {
+				err = r
+				continue
+			}
+
+			vals = append(vals, r.Interface())
+		}
+
+		return []reflect.Value{reflect.ValueOf(vals), err}
+	}).Interface()
+}
+
+// resultGetter creates a function that receives the results of a multi-output factory and returns the one on the given
+// index as the provided type.
+func resultGetter(index int, rt reflect.Type) any {
+	ftype := reflect.FuncOf([]reflect.Type{resultsType}, []reflect.Type{rt}, false)
+
+	return reflect.MakeFunc(ftype, func(in []reflect.Value) []reflect.Value {
+		val := reflect.New(rt).Elem()
+
+		if res := in[0].Interface().(results); res[index] != nil {
+			val.Set(reflect.ValueOf(res[index]))
+		}
+
+		return []reflect.Value{val}
+	}).Interface()
+}
+
+// getShared returns the instance of the dependency built on the current top level resolution, building it if it is the
+// first time it is asked for.
+func (c *Container) getShared(name types.Symbol, dep dependency.Dependency, r resolver) (any, error) {
+	key := sharedKey{container: c, name: name}
+
+	if val, ok := r.state.shared[key]; ok {
+		return val, nil
+	}
+
+	val, err := c.getInstance(name, dep, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.state.shared == nil {
+		r.state.shared = make(map[sharedKey]any)
+	}
+
+	r.state.shared[key] = val
+
+	return val, nil
+}
+
+// fieldGetter creates a function that receives a value of the given struct type, or pointer to struct, and returns
+// the value of the field.
+func fieldGetter(rt reflect.Type, field types.OutField) any {
+	ftype := reflect.FuncOf([]reflect.Type{rt}, []reflect.Type{field.Type}, false)
+
+	return reflect.MakeFunc(ftype, func(in []reflect.Value) []reflect.Value {
+		out := in[0]
+
+		if out.Kind() == reflect.Ptr {
+			if out.IsNil() {
+				return []reflect.Value{reflect.Zero(field.Type)}
+			}
+
+			out = out.Elem()
+		}
+
+		return []reflect.Value{out.FieldByIndex(field.Index)}
+	}).Interface()
+}
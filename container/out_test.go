@@ -0,0 +1,279 @@
+package container
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Drafteame/inject/dependency"
+	"github.com/Drafteame/inject/types"
+)
+
+type configs struct {
+	types.Out
+	DB      string `inject:"name=config.db"`
+	Port    int    `inject:"name=config.port"`
+	Ignored bool
+}
+
+func TestContainer_ProvideOut(t *testing.T) {
+	t.Run("register each named field", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("config", dependency.New(func() configs {
+			return configs{DB: "main", Port: 8080}
+		})); err != nil {
+			t.Error(err)
+			return
+		}
+
+		db, err := ic.Get("config.db")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "main", db)
+
+		port, err := ic.Resolve(reflect.TypeOf(0))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 8080, port)
+
+		_, err = ic.Get("config.Ignored")
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+	})
+
+	t.Run("share factory build on the same resolution", func(t *testing.T) {
+		ic := New()
+		calls := 0
+
+		if err := ic.Provide("config", dependency.New(func() *configs {
+			calls++
+			return &configs{DB: "main", Port: 8080}
+		})); err != nil {
+			t.Error(err)
+			return
+		}
+
+		type args struct {
+			types.In
+			DB   string `inject:"name=config.db"`
+			Port int    `inject:"name=config.port"`
+		}
+
+		err := ic.Invoke(func(in args) {
+			assert.Equal(t, "main", in.DB)
+			assert.Equal(t, 8080, in.Port)
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, calls)
+
+		_, err = ic.Get("config.db")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("share singleton factory build", func(t *testing.T) {
+		ic := New()
+		calls := 0
+
+		if err := ic.Provide("config", dependency.NewSingleton(func() configs {
+			calls++
+			return configs{DB: "main"}
+		})); err != nil {
+			t.Error(err)
+			return
+		}
+
+		for _, name := range []types.Symbol{"config.db", "config.port", "config.db"} {
+			if _, err := ic.Get(name); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("duplicated field name", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("config.db", dependency.New(func() string { return "other" })); err != nil {
+			t.Error(err)
+			return
+		}
+
+		err := ic.Provide("config", dependency.New(func() configs { return configs{} }))
+
+		assert.ErrorIs(t, err, types.ErrDuplicate)
+
+		_, ok := ic.Lookup("config")
+
+		assert.False(t, ok)
+	})
+
+	t.Run("empty field name", func(t *testing.T) {
+		type invalid struct {
+			types.Out
+			DB string `inject:"name="`
+		}
+
+		ic := New()
+
+		err := ic.Provide("config", dependency.New(func() invalid { return invalid{} }))
+
+		assert.EqualError(t, err, "inject: empty name tag of output dependency on field `DB`")
+	})
+}
+
+type reader struct{ source string }
+
+type writer struct{ source string }
+
+func TestContainer_ProvideMultiOutput(t *testing.T) {
+	t.Run("register each output by name and type", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("reader", dependency.New(func(source string) (*reader, *writer, error) {
+			return &reader{source: source}, &writer{source: source}, nil
+		}, "file"), WithOutputNames("writer")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		r, err := ic.Get("reader")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &reader{source: "file"}, r)
+
+		w, err := ic.Get("writer")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &writer{source: "file"}, w)
+
+		w, err = ic.Resolve(reflect.TypeOf(&writer{}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, &writer{source: "file"}, w)
+	})
+
+	t.Run("register unnamed outputs by type", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.ProvideType(dependency.New(func() (*reader, *writer) {
+			return &reader{}, &writer{}
+		})); err != nil {
+			t.Error(err)
+			return
+		}
+
+		_, err := ic.Resolve(reflect.TypeOf(&reader{}))
+
+		assert.NoError(t, err)
+
+		_, err = ic.Resolve(reflect.TypeOf(&writer{}))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("call factory once on the same resolution", func(t *testing.T) {
+		ic := New()
+		calls := 0
+
+		if err := ic.Provide("reader", dependency.New(func() (*reader, *writer, error) {
+			calls++
+			return &reader{}, &writer{}, nil
+		}), WithOutputNames("writer")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		type args struct {
+			types.In
+			W *writer `inject:"name=writer"`
+			R *reader `inject:"name=reader"`
+		}
+
+		err := ic.Invoke(func(in args) {
+			assert.NotNil(t, in.W)
+			assert.NotNil(t, in.R)
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("call singleton factory once", func(t *testing.T) {
+		ic := New()
+		calls := 0
+
+		if err := ic.Provide("reader", dependency.NewSingleton(func() (*reader, *writer) {
+			calls++
+			return &reader{}, &writer{}
+		}), WithOutputNames("writer")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		for _, name := range []types.Symbol{"writer", "reader", "writer"} {
+			if _, err := ic.Get(name); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("factory error", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("reader", dependency.New(func() (*reader, *writer, error) {
+			return nil, nil, assert.AnError
+		}), WithOutputNames("writer")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		_, err := ic.Get("writer")
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("error output that is not the last one", func(t *testing.T) {
+		ic := New()
+
+		err := ic.Provide("reader", dependency.New(func() (*reader, error, *writer) {
+			return nil, nil, nil
+		}))
+
+		assert.EqualError(t, err, "inject: factory `func() (*container.reader, error, *container.writer)` can only return an error as its last result")
+	})
+
+	t.Run("too many output names", func(t *testing.T) {
+		ic := New()
+
+		err := ic.Provide("reader", dependency.New(func() (*reader, *writer) {
+			return nil, nil
+		}), WithOutputNames("writer", "other"))
+
+		assert.EqualError(t, err, "inject: factory `func() (*container.reader, *container.writer)` has 1 extra outputs, got 2 output names")
+	})
+
+	t.Run("duplicated output name", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("writer", dependency.New(func() string { return "other" })); err != nil {
+			t.Error(err)
+			return
+		}
+
+		err := ic.Provide("reader", dependency.New(func() (*reader, *writer) {
+			return nil, nil
+		}), WithOutputNames("writer"))
+
+		assert.ErrorIs(t, err, types.ErrDuplicate)
+	})
+}
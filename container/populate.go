@@ -0,0 +1,47 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/Drafteame/inject/types"
+)
+
+// Populate fills the fields of an existing struct, pointed by `target`, that have an `inject` tag, like a test suite or
+// a command, e.g:
+//
+//	type suite struct {
+//		DB     *sql.DB `inject:"name=db"`
+//		Mailer Mailer  `inject:""`
+//		name   string
+//	}
+//
+// Tags have the same options as the fields of a `types.In` struct, and a field tagged with no name nor group is filled
+// by its type. Untagged fields, and tagged fields that are unexported, are left alone.
+func (c *Container) Populate(target any) error {
+	return c.populate(target, false)
+}
+
+// PopulateStrict is the same as Populate, but it fails if a tagged field can't be set because it is unexported.
+func (c *Container) PopulateStrict(target any) error {
+	return c.populate(target, true)
+}
+
+func (c *Container) populate(target any, strict bool) error {
+	if target == nil {
+		return fmt.Errorf("inject: can't populate nil target")
+	}
+
+	if err := c.checkOpen(); err != nil {
+		return err
+	}
+
+	r := c.newResolver(context.Background())
+
+	if err := types.Populate(r, reflect.ValueOf(target), strict); err != nil {
+		return r.err(err)
+	}
+
+	return nil
+}
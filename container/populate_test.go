@@ -0,0 +1,109 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Drafteame/inject/dependency"
+	"github.com/Drafteame/inject/types"
+)
+
+func TestContainer_Populate(t *testing.T) {
+	newContainer := func(t *testing.T) *Container {
+		ic := New()
+
+		if err := ic.Provide("user", dependency.New(newUser, "John", 21)); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ic.Provide("driver", dependency.NewSingleton(newDriver, "main").As(new(database))); err != nil {
+			t.Fatal(err)
+		}
+
+		return ic
+	}
+
+	t.Run("fill tagged fields", func(t *testing.T) {
+		ic := newContainer(t)
+
+		target := struct {
+			User     *user    `inject:"name=user"`
+			DB       database `inject:""`
+			Missing  *user    `inject:"name=missing,optional"`
+			Untagged *user
+			unexp    *user `inject:"name=user"`
+		}{}
+
+		err := ic.Populate(&target)
+
+		if assert.NoError(t, err) && assert.NotNil(t, target.User) && assert.NotNil(t, target.DB) {
+			assert.Equal(t, "John", target.User.getName())
+			assert.Equal(t, "main", target.DB.client())
+		}
+
+		assert.Nil(t, target.Missing)
+		assert.Nil(t, target.Untagged)
+		assert.Nil(t, target.unexp)
+	})
+
+	t.Run("keep values of untagged fields", func(t *testing.T) {
+		ic := newContainer(t)
+
+		target := struct {
+			types.In
+			User *user `inject:"name=user"`
+			Name string
+		}{Name: "suite"}
+
+		err := ic.Populate(&target)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, target.User)
+		assert.Equal(t, "suite", target.Name)
+	})
+
+	t.Run("strict mode fails on unexported fields", func(t *testing.T) {
+		ic := newContainer(t)
+
+		type suite struct {
+			User *user    `inject:"name=user"`
+			db   database `inject:""`
+		}
+
+		err := ic.PopulateStrict(&suite{})
+
+		assert.EqualError(t, err, "inject: can't set unexported field `db` of `container.suite`")
+	})
+
+	t.Run("missing dependency", func(t *testing.T) {
+		ic := newContainer(t)
+
+		target := struct {
+			User *user `inject:"name=missing"`
+		}{}
+
+		err := ic.Populate(&target)
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+	})
+
+	t.Run("wrong field type", func(t *testing.T) {
+		ic := newContainer(t)
+
+		target := struct {
+			User string `inject:"name=user"`
+		}{}
+
+		err := ic.Populate(&target)
+
+		assert.EqualError(t, err, "inject: using *container.user as type string for field `User`")
+	})
+
+	t.Run("invalid target", func(t *testing.T) {
+		ic := newContainer(t)
+
+		assert.EqualError(t, ic.Populate(nil), "inject: can't populate nil target")
+		assert.EqualError(t, ic.Populate(struct{}{}), "inject: populate target should be a non nil pointer to a struct, got `struct {}`")
+	})
+}
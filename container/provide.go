@@ -2,6 +2,7 @@ package container
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/Drafteame/inject/dependency"
 	"github.com/Drafteame/inject/types"
@@ -11,21 +12,136 @@ import (
 // Provide It adds a new injection dependency to the Container, getting the first result type of the constructor to
 // associate the constructor on the injection dependency threes, e.g:
 //
-// inject.get().Provide(dependency.New(callback, arg1, arg2), inject.As(new(someInterface)))
+// inject.get().Provide("name", dependency.New(callback, arg1, arg2).As(new(someInterface)))
 //
 // This injection will be resolved and built on execution time when the `inject.get().Invoke(...)` method is called.
-func (c *Container) Provide(name types.Symbol, dep dependency.Dependency) error {
-	var err error
+// The dependency can also be resolved by its first return type, or by any of the interfaces it was bound to, using the
+// `Resolve` method.
+//
+// If the factory returns a struct that embeds `types.Out`, each of its fields tagged with a name is also registered as
+// its own dependency, and all of them are backed by the same build of the factory.
+//
+// The dependency can be configured with options like `WithSingleton()` or `WithGroup(...)`, that are applied in order.
+func (c *Container) Provide(name types.Symbol, dep dependency.Dependency, opts ...ProvideOption) error {
+	dep = applyOptions(dep, opts)
+
+	p, err := newProvision(name, dep)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return types.ErrClosed
+	}
+
+	if err := c.checkOutputs(name, p.outputs); err != nil {
+		return err
+	}
+
+	c.deps, err = c.provide(c.deps, name, p.dep)
+	if err != nil {
+		return err
+	}
+
+	c.index(p)
+
+	return nil
+}
+
+// provision is a dependency that is ready to be registered on a container, along with the types it should be
+// resolvable by and the outputs it declares.
+type provision struct {
+	name    types.Symbol
+	dep     dependency.Dependency
+	rts     []reflect.Type
+	outputs []output
+	private bool
+}
+
+// newProvision makes every check over the dependency that doesn't depend on the state of the container. The outputs of
+// a multi-output factory, and the fields of a returned `types.Out` struct, are returned as outputs of the provision.
+func newProvision(name types.Symbol, dep dependency.Dependency) (provision, error) {
+	rt := utils.GetFirstReturnType(dep.Factory)
+	if rt == nil {
+		return provision{}, fmt.Errorf("inject: dependency factory should return at least one return type: %s", dep.String())
+	}
+
+	rts, err := getResolvableTypes(rt, dep)
+	if err != nil {
+		return provision{}, err
+	}
+
+	if err := dep.CheckKeys(); err != nil {
+		return provision{}, err
+	}
+
+	var results []output
+
+	if outs := utils.GetOutputTypes(dep.Factory); len(outs) > 1 {
+		dep, results, err = splitResults(name, dep, outs)
+		if err != nil {
+			return provision{}, err
+		}
+	}
+
+	outputs, err := getOutputs(name, dep)
+	if err != nil {
+		return provision{}, err
+	}
+
+	return provision{name: name, dep: dep, rts: rts, outputs: append(results, outputs...)}, nil
+}
+
+// index associates an already registered dependency to its types and groups, and registers its outputs, returning the
+// names they were registered with. Private dependencies can only be resolved by name, so they are not indexed. It
+// should be called holding the container lock.
+func (c *Container) index(p provision) []types.Symbol {
+	if !p.private {
+		c.indexType(p.rts, p.name)
+		c.indexGroups(p.dep.Groups, p.name)
+	}
+
+	return c.provideOutputs(p.outputs, !p.private)
+}
+
+// ProvideType adds a new injection dependency to the Container without a name. The dependency can only be resolved by
+// the first return type of its factory, using the `Resolve` method or an untagged field of an `types.In` struct.
+func (c *Container) ProvideType(dep dependency.Dependency, opts ...ProvideOption) error {
+	dep = applyOptions(dep, opts)
 
-	if rt := utils.GetFirstReturnType(dep.Factory); rt == nil {
+	rt := utils.GetFirstReturnType(dep.Factory)
+	if rt == nil {
 		return fmt.Errorf("inject: dependency factory should return at least one return type: %s", dep.String())
 	}
 
-	c.deps, err = c.provide(c.deps, name, dep)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return types.ErrClosed
+	}
+
+	name := c.typeSymbol(rt)
+
+	p, err := newProvision(name, dep)
 	if err != nil {
 		return err
 	}
 
+	if err := c.checkOutputs(name, p.outputs); err != nil {
+		return err
+	}
+
+	if c.deps == nil {
+		c.deps = make(map[types.Symbol]dependency.Dependency)
+	}
+
+	c.deps[name] = p.dep
+	c.index(p)
+
 	return nil
 }
 
@@ -42,10 +158,97 @@ func (c *Container) provide(container map[types.Symbol]dependency.Dependency, na
 	}
 
 	if _, ok := container[name]; ok {
-		return container, fmt.Errorf("inject: duplicated dependency name `%s`", name)
+		return container, &types.DuplicateError{Name: name}
 	}
 
 	container[name] = dep
 
 	return container, nil
 }
+
+// indexType associates the dependency name to the given types, so it can be resolved by any of them. It should be
+// called holding the container lock.
+func (c *Container) indexType(rts []reflect.Type, name types.Symbol) {
+	if c.byType == nil {
+		c.byType = make(map[reflect.Type][]types.Symbol)
+	}
+
+	for _, rt := range rts {
+		c.byType[rt] = append(c.byType[rt], name)
+	}
+}
+
+// indexGroups adds the dependency name as the last member of each provided group, so groups are resolved in
+// registration order. It should be called holding the container lock.
+func (c *Container) indexGroups(groups []types.Symbol, name types.Symbol) {
+	if c.groups == nil {
+		c.groups = make(map[types.Symbol][]types.Symbol)
+	}
+
+	for _, group := range groups {
+		if !containsSymbol(c.groups[group], name) {
+			c.groups[group] = append(c.groups[group], name)
+		}
+	}
+}
+
+// getResolvableTypes returns the types that a dependency should be indexed by: the factory return type followed by every
+// interface the dependency was bound to with `dependency.Dependency.As`. Each bound value should be a pointer to an
+// interface that is implemented by the return type.
+func getResolvableTypes(rt reflect.Type, dep dependency.Dependency) ([]reflect.Type, error) {
+	rts := []reflect.Type{rt}
+
+	for _, iface := range dep.Interfaces {
+		itype := reflect.TypeOf(iface)
+
+		if itype == nil || itype.Kind() != reflect.Ptr || itype.Elem().Kind() != reflect.Interface {
+			return nil, fmt.Errorf("inject: dependency should be bound to a pointer to an interface, got `%v`", itype)
+		}
+
+		itype = itype.Elem()
+
+		if !rt.Implements(itype) {
+			return nil, fmt.Errorf("inject: type `%v` does not implement `%v`", rt, itype)
+		}
+
+		if !containsType(rts, itype) {
+			rts = append(rts, itype)
+		}
+	}
+
+	return rts, nil
+}
+
+// typeSymbol generates the internal name of a dependency registered without a name, based on its type. If that name
+// is already taken, a numeric suffix is added to make it unique. It should be called holding the container lock.
+func (c *Container) typeSymbol(rt reflect.Type) types.Symbol {
+	name := types.Symbol(fmt.Sprintf("<%v>", rt))
+
+	for i := 2; ; i++ {
+		if _, ok := c.deps[name]; !ok {
+			return name
+		}
+
+		name = types.Symbol(fmt.Sprintf("<%v>#%d", rt, i))
+	}
+}
+
+func containsType(rts []reflect.Type, rt reflect.Type) bool {
+	for _, t := range rts {
+		if t == rt {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsSymbol(names []types.Symbol, name types.Symbol) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
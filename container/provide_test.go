@@ -3,6 +3,7 @@ package container
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -51,7 +52,8 @@ func TestContainer_Provide(t *testing.T) {
 		expErr := fmt.Errorf("inject: duplicated dependency name `%s`", userDepName)
 
 		assert.Error(t, err)
-		assert.Equal(t, expErr, err)
+		assert.EqualError(t, err, expErr.Error())
+		assert.ErrorIs(t, err, types.ErrDuplicate)
 	})
 
 	t.Run("provide dependency with no return value constructor", func(t *testing.T) {
@@ -98,7 +100,7 @@ func TestContainer_Provide(t *testing.T) {
 		expErr := fmt.Errorf("inject: duplicated dependency name `%s`", userDepName)
 
 		assert.Error(t, err)
-		assert.Equal(t, expErr, err)
+		assert.EqualError(t, err, expErr.Error())
 	})
 
 	t.Run("provide singleton dependency with no return value constructor", func(t *testing.T) {
@@ -131,6 +133,15 @@ func TestContainer_Provide(t *testing.T) {
 		assert.Equal(t, expErr, err)
 	})
 
+	t.Run("provide dependency with mismatched key argument", func(t *testing.T) {
+		ic := New()
+
+		err := ic.Provide("user", dependency.New(newUserWithDriver, dependency.InjectKey(types.NewKey[*user]("driver"))))
+
+		assert.EqualError(t, err, "inject: using *container.user as type container.database on constructor `func(container.database) *container.user`")
+		assert.NotContains(t, ic.deps, types.Symbol("user"))
+	})
+
 	t.Run("wrong container initialization solved", func(t *testing.T) {
 		const name = "John Smith"
 		const age = 21
@@ -146,3 +157,95 @@ func TestContainer_Provide(t *testing.T) {
 		assert.NotEmpty(t, ic.deps[userDepName])
 	})
 }
+
+func TestContainer_ProvideAs(t *testing.T) {
+	t.Run("resolve dependency by bound interface", func(t *testing.T) {
+		ic := New()
+
+		dep := dependency.NewSingleton(newUser, "John", 21).As(new(namer), new(ager))
+
+		if err := ic.Provide("user", dep); err != nil {
+			t.Error(err)
+			return
+		}
+
+		n, err := ic.Resolve(reflect.TypeOf(new(namer)).Elem())
+		assert.NoError(t, err)
+
+		a, err := ic.Resolve(reflect.TypeOf(new(ager)).Elem())
+		assert.NoError(t, err)
+
+		u, err := ic.Resolve(reflect.TypeOf(&user{}))
+		assert.NoError(t, err)
+
+		assert.Same(t, u, n)
+		assert.Same(t, u, a)
+
+		_, err = ic.Resolve(reflect.TypeOf(new(userer)).Elem())
+
+		assert.EqualError(t, err, "inject: no provided dependency of type `container.userer`")
+	})
+
+	t.Run("fill invoke fields by bound interface", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.ProvideType(dependency.New(newDriver, "main").As(new(database))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		type args struct {
+			types.In
+			DB database
+		}
+
+		called := false
+
+		err := ic.Invoke(func(in args) {
+			if assert.NotNil(t, in.DB) {
+				assert.Equal(t, "main", in.DB.client())
+			}
+
+			called = true
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, called)
+	})
+
+	t.Run("bind to the same interface twice", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("user", dependency.New(newUser, "John", 21).As(new(namer)).As(new(namer))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		_, err := ic.Resolve(reflect.TypeOf(new(namer)).Elem())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("bind to a non implemented interface", func(t *testing.T) {
+		ic := New()
+
+		err := ic.Provide("user", dependency.New(newUser, "John", 21).As(new(database)))
+
+		expErr := errors.New("inject: type `*container.user` does not implement `container.database`")
+
+		assert.Error(t, err)
+		assert.Equal(t, expErr, err)
+		assert.Empty(t, ic.deps)
+	})
+
+	t.Run("bind to a non interface value", func(t *testing.T) {
+		ic := New()
+
+		err := ic.ProvideType(dependency.New(newUser, "John", 21).As(user{}))
+
+		expErr := errors.New("inject: dependency should be bound to a pointer to an interface, got `container.user`")
+
+		assert.Error(t, err)
+		assert.Equal(t, expErr, err)
+	})
+}
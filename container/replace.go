@@ -0,0 +1,227 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/Drafteame/inject/dependency"
+	"github.com/Drafteame/inject/types"
+)
+
+// Replace swaps the registration of the dependency with the given name, which should be already provided on the
+// container. Any cached instance of the dependency, and of every singleton or scoped dependency that depends on it
+// directly or transitively, is dropped, so it will be built again with the new registration. That includes the
+// scoped instances cached by the scopes created from the container, and the builds of those dependencies that are
+// still running, whose result is not cached. Dropped instances are not closed.
+//
+// Dependencies that declare outputs, like multi-output factories or factories that return a `types.Out` struct, can't
+// be replaced nor replace others, since their outputs are registered as dependencies of their own. Each output can be
+// replaced on its own instead.
+//
+// It is meant to override dependencies with mocks on tests, along with Snapshot and Restore.
+func (c *Container) Replace(name types.Symbol, dep dependency.Dependency) error {
+	p, err := newProvision(name, dep)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return types.ErrClosed
+	}
+
+	if _, ok := c.deps[name]; !ok {
+		return &types.NotFoundError{Name: name}
+	}
+
+	if len(p.outputs) > 0 || c.declaresOutputs(name) {
+		return fmt.Errorf("inject: dependency `%s` can't be replaced, since it declares outputs", name)
+	}
+
+	c.unindex(name)
+
+	// Private module dependencies can only be resolved by name, as when they are installed.
+	p.private = c.members[name].private
+	c.deps[name] = p.dep
+	c.index(p)
+
+	// Scopes compare the generation with the last one they saw, to drop their own instances of the dependents.
+	if c.replaced == nil {
+		c.replaced = make(map[types.Symbol]uint64)
+	}
+
+	c.generation++
+	c.replaced[name] = c.generation
+
+	for _, dependent := range c.dependents(name) {
+		c.dropInstance(dependent)
+	}
+
+	return nil
+}
+
+// dropReplaced drops the instances cached by the scope that depend on dependencies replaced on any of its parents since
+// the last time it was checked. It should be called without holding any container lock.
+func (c *Container) dropReplaced() {
+	c.mu.RLock()
+	seen := copyMap(c.seen)
+	c.mu.RUnlock()
+
+	names := make([]types.Symbol, 0)
+	generations := make(map[*Container]uint64)
+
+	for _, parent := range c.chain()[1:] {
+		parent.mu.RLock()
+
+		if parent.generation > seen[parent] {
+			generations[parent] = parent.generation
+
+			for name, generation := range parent.replaced {
+				if generation > seen[parent] && !containsSymbol(names, name) {
+					names = append(names, name)
+				}
+			}
+		}
+
+		parent.mu.RUnlock()
+	}
+
+	if len(generations) == 0 {
+		return
+	}
+
+	dependents := c.scopeDependents(names)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, dependent := range dependents {
+		c.dropInstance(dependent)
+	}
+
+	if c.seen == nil {
+		c.seen = make(map[*Container]uint64)
+	}
+
+	for parent, generation := range generations {
+		if generation > c.seen[parent] {
+			c.seen[parent] = generation
+		}
+	}
+}
+
+// scopeDependents is the same as dependents, but it looks into every dependency that can be resolved from the scope.
+// It should be called without holding any container lock.
+func (c *Container) scopeDependents(names []types.Symbol) []types.Symbol {
+	found := append([]types.Symbol{}, names...)
+	symbols := c.visibleSymbols()
+
+	for i := 0; i < len(found); i++ {
+		for _, name := range symbols {
+			if containsSymbol(found, name) {
+				continue
+			}
+
+			if dep, _, _ := c.lookup(name); containsSymbol(dep.References(), found[i]) || c.decoratedWith(name, found[i]) {
+				found = append(found, name)
+			}
+		}
+	}
+
+	return found
+}
+
+// decoratedWith returns true if any decorator of the dependency, registered on the scope or any of its parents,
+// injects the given name. It should be called without holding any container lock.
+func (c *Container) decoratedWith(name, ref types.Symbol) bool {
+	for _, scope := range c.chain() {
+		scope.mu.RLock()
+		ok := scope.decoratorReferences(name, ref)
+		scope.mu.RUnlock()
+
+		if ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unindex removes the dependency name from the type and group indexes. It should be called holding the container
+// lock.
+func (c *Container) unindex(name types.Symbol) {
+	for rt, names := range c.byType {
+		c.byType[rt] = removeSymbol(names, name)
+
+		if len(c.byType[rt]) == 0 {
+			delete(c.byType, rt)
+		}
+	}
+
+	for group, names := range c.groups {
+		c.groups[group] = removeSymbol(names, name)
+
+		if len(c.groups[group]) == 0 {
+			delete(c.groups, group)
+		}
+	}
+}
+
+// dependents returns the given name along with the names of every dependency of the container that references it,
+// directly or transitively. It should be called holding the container lock.
+func (c *Container) dependents(name types.Symbol) []types.Symbol {
+	found := []types.Symbol{name}
+
+	for i := 0; i < len(found); i++ {
+		for dname, dep := range c.deps {
+			if containsSymbol(found, dname) {
+				continue
+			}
+
+			if containsSymbol(dep.References(), found[i]) || c.decoratorReferences(dname, found[i]) {
+				found = append(found, dname)
+			}
+		}
+	}
+
+	return found
+}
+
+// decoratorReferences returns true if any decorator of the dependency injects the given name. It should be called
+// holding the container lock.
+func (c *Container) decoratorReferences(name, ref types.Symbol) bool {
+	for _, d := range c.decorators[name] {
+		if containsSymbol(d.dependency(reflect.TypeOf(d.fn).In(0), nil).References(), ref) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// dropInstance deletes the cached instance of the dependency, if any, and discards its build if it is still running.
+// It should be called holding the container lock.
+func (c *Container) dropInstance(name types.Symbol) {
+	delete(c.inflight, name)
+
+	if _, ok := c.solvedDeps[name]; !ok {
+		return
+	}
+
+	delete(c.solvedDeps, name)
+	c.built = removeSymbol(c.built, name)
+}
+
+func removeSymbol(names []types.Symbol, name types.Symbol) []types.Symbol {
+	res := make([]types.Symbol, 0, len(names))
+
+	for _, n := range names {
+		if n != name {
+			res = append(res, n)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,269 @@
+package container
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Drafteame/inject/dependency"
+	"github.com/Drafteame/inject/types"
+)
+
+func TestContainer_Replace(t *testing.T) {
+	t.Run("replace dependency and drop dependent singletons", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("driver", dependency.NewSingleton(newDriver, "main")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("user", dependency.NewSingleton(newUserWithDriver, dependency.Inject("driver"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("todo", dependency.NewSingleton(newTodo, dependency.New(newDriver, "aux"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		for _, name := range []types.Symbol{"user", "todo"} {
+			if _, err := ic.Get(name); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		err := ic.Replace("driver", dependency.NewSingleton(newDriver, "mock"))
+
+		assert.NoError(t, err)
+		assert.Len(t, ic.solvedDeps, 1)
+		assert.Contains(t, ic.solvedDeps, types.Symbol("todo"))
+
+		val, err := ic.Get("user")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "mock", val.(*user).getDb().client())
+		}
+	})
+
+	t.Run("replace updates type and group indexes", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("driver", dependency.New(newDriver, "main").InGroup("drivers")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		err := ic.Replace("driver", dependency.New(func() database { return newDriver("mock") }))
+		assert.NoError(t, err)
+
+		_, err = ic.Resolve(reflect.TypeOf(&driver{}))
+		assert.EqualError(t, err, "inject: no provided dependency of type `*container.driver`")
+
+		val, err := ic.Resolve(reflect.TypeOf(new(database)).Elem())
+		if assert.NoError(t, err) {
+			assert.Equal(t, "mock", val.(database).client())
+		}
+
+		vals, err := ic.GetGroup("drivers")
+		assert.NoError(t, err)
+		assert.Empty(t, vals)
+	})
+
+	t.Run("replace private module dependency", func(t *testing.T) {
+		ic := New()
+
+		users := NewModule("users",
+			Private("driver", dependency.New(newDriver, "main")),
+			Register("service", dependency.New(newUserWithDriver, dependency.Inject("driver"))),
+		)
+
+		if err := ic.Install(users); err != nil {
+			t.Error(err)
+			return
+		}
+
+		err := ic.Replace("users.driver", dependency.New(newDriver, "mock").InGroup("drivers"))
+		assert.NoError(t, err)
+
+		_, err = ic.Resolve(reflect.TypeOf(&driver{}))
+		assert.ErrorIs(t, err, types.ErrNotFound)
+
+		vals, err := ic.GetGroup("drivers")
+		assert.NoError(t, err)
+		assert.Empty(t, vals)
+
+		val, err := ic.Get("users.service")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "mock", val.(*user).getDb().client())
+		}
+	})
+
+	t.Run("replace dependency with outputs", func(t *testing.T) {
+		ic := New()
+
+		pipe := dependency.New(func() (*reader, *writer) {
+			return &reader{source: "pipe"}, &writer{source: "pipe"}
+		})
+
+		if err := ic.Provide("r", pipe, WithOutputNames("w")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("other", dependency.New(func() *reader { return &reader{source: "other"} })); err != nil {
+			t.Error(err)
+			return
+		}
+
+		err := ic.Replace("r", dependency.New(func() *reader { return &reader{source: "mock"} }))
+		assert.EqualError(t, err, "inject: dependency `r` can't be replaced, since it declares outputs")
+
+		err = ic.Replace("other", pipe)
+		assert.EqualError(t, err, "inject: dependency `other` can't be replaced, since it declares outputs")
+
+		err = ic.Replace("w", dependency.New(func() *writer { return &writer{source: "mock"} }))
+		assert.NoError(t, err)
+
+		val, err := ic.Get("w")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "mock", val.(*writer).source)
+		}
+
+		val, err = ic.Get("r")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "pipe", val.(*reader).source)
+		}
+	})
+
+	t.Run("drop dependent scoped instances of scopes", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("a", dependency.New(func() string { return "old" })); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("b", dependency.NewScoped(func(a string) string { return "b:" + a }, dependency.Inject("a"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		scope := ic.Scope("request")
+
+		val, err := scope.Get("b")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "b:old", val)
+		}
+
+		assert.NoError(t, ic.Replace("a", dependency.New(func() string { return "new" })))
+
+		val, err = scope.Get("b")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "b:new", val)
+		}
+	})
+
+	t.Run("discard running builds of dependents", func(t *testing.T) {
+		ic := New()
+		started, release := make(chan struct{}), make(chan struct{})
+
+		if err := ic.Provide("a", dependency.New(func() string { return "old" })); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("b", dependency.NewSingleton(func(a string) string {
+			if a == "old" {
+				close(started)
+				<-release
+			}
+
+			return "b:" + a
+		}, dependency.Inject("a"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		vals := make(chan any, 1)
+
+		go func() {
+			val, _ := ic.Get("b")
+			vals <- val
+		}()
+
+		<-started
+
+		assert.NoError(t, ic.Replace("a", dependency.New(func() string { return "new" })))
+
+		close(release)
+
+		assert.Equal(t, "b:old", <-vals)
+
+		val, err := ic.Get("b")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "b:new", val)
+		}
+	})
+
+	t.Run("replace not provided dependency", func(t *testing.T) {
+		ic := New()
+
+		err := ic.Replace("driver", dependency.New(newDriver, "mock"))
+
+		expErr := errors.New("inject: no provided dependency of name `driver`")
+
+		assert.Error(t, err)
+		assert.EqualError(t, err, expErr.Error())
+	})
+}
+
+func TestContainer_Snapshot(t *testing.T) {
+	ic := New()
+
+	if err := ic.Provide("driver", dependency.NewSingleton(newDriver, "main")); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := ic.Provide("user", dependency.NewSingleton(newUserWithDriver, dependency.Inject("driver"))); err != nil {
+		t.Error(err)
+		return
+	}
+
+	original, err := ic.Get("user")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	snap := ic.Snapshot()
+
+	t.Run("override dependencies", func(t *testing.T) {
+		t.Cleanup(func() { ic.Restore(snap) })
+
+		if err := ic.Replace("driver", dependency.NewSingleton(newDriver, "mock")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("todo", dependency.New(newTodo, dependency.Inject("driver"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		val, err := ic.Get("user")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "mock", val.(*user).getDb().client())
+		}
+	})
+
+	val, err := ic.Get("user")
+
+	assert.NoError(t, err)
+	assert.Same(t, original, val)
+	assert.NotContains(t, ic.deps, types.Symbol("todo"))
+}
@@ -0,0 +1,58 @@
+package container
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/Drafteame/inject/types"
+)
+
+// Resolve returns an instance of the only dependency that was provided for the given type. It fails if there is no
+// dependency provided for that type, or if there is more than one, since it can't choose between them.
+func (c *Container) Resolve(rtype reflect.Type) (any, error) {
+	r := c.newResolver(context.Background())
+
+	val, err := r.Resolve(rtype)
+	if err != nil {
+		return nil, r.err(err)
+	}
+
+	return val, nil
+}
+
+// Resolve finds the name of the only dependency provided for the given type and resolves it on the current build path.
+func (r resolver) Resolve(rtype reflect.Type) (any, error) {
+	name, err := r.container.symbolOf(rtype)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Get(name)
+}
+
+// symbolOf returns the name of the only dependency that was provided for the given type, on this container or any of
+// its parents.
+func (c *Container) symbolOf(rtype reflect.Type) (types.Symbol, error) {
+	names := make([]types.Symbol, 0)
+
+	for _, scope := range c.chain() {
+		scope.mu.RLock()
+
+		for _, name := range scope.byType[rtype] {
+			if !containsSymbol(names, name) {
+				names = append(names, name)
+			}
+		}
+
+		scope.mu.RUnlock()
+	}
+
+	switch len(names) {
+	case 0:
+		return "", &types.NotFoundError{Type: rtype}
+	case 1:
+		return names[0], nil
+	default:
+		return "", &types.AmbiguousError{Type: rtype, Names: names}
+	}
+}
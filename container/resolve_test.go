@@ -0,0 +1,113 @@
+package container
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Drafteame/inject/dependency"
+	"github.com/Drafteame/inject/types"
+)
+
+func TestContainer_Resolve(t *testing.T) {
+	userType := reflect.TypeOf(&user{})
+
+	t.Run("resolve unnamed dependency by type", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.ProvideType(dependency.New(newUser, "John", 21)); err != nil {
+			t.Error(err)
+			return
+		}
+
+		val, err := ic.Resolve(userType)
+
+		if assert.NoError(t, err) && assert.IsType(t, &user{}, val) {
+			assert.Equal(t, "John", val.(*user).getName())
+		}
+	})
+
+	t.Run("resolve named dependency by type", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("user", dependency.NewSingleton(newUser, "John", 21)); err != nil {
+			t.Error(err)
+			return
+		}
+
+		val, err := ic.Resolve(userType)
+		assert.NoError(t, err)
+
+		named, err := ic.Get("user")
+		assert.NoError(t, err)
+
+		assert.Same(t, named, val)
+	})
+
+	t.Run("resolve type with no provider", func(t *testing.T) {
+		ic := New()
+
+		_, err := ic.Resolve(userType)
+
+		expErr := errors.New("inject: no provided dependency of type `*container.user`")
+
+		assert.Error(t, err)
+		assert.EqualError(t, err, expErr.Error())
+	})
+
+	t.Run("resolve type with many providers", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("john", dependency.New(newUser, "John", 21)); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.ProvideType(dependency.New(newUser, "Jane", 22)); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.ProvideType(dependency.New(newUser, "Joe", 23)); err != nil {
+			t.Error(err)
+			return
+		}
+
+		_, err := ic.Resolve(userType)
+
+		expErr := errors.New("inject: multiple provided dependencies of type `*container.user`: `john`, `<*container.user>`, `<*container.user>#2`")
+
+		assert.Error(t, err)
+		assert.EqualError(t, err, expErr.Error())
+		assert.ErrorIs(t, err, types.ErrAmbiguous)
+	})
+
+	t.Run("provide unnamed dependency with no return value constructor", func(t *testing.T) {
+		ic := New()
+
+		err := ic.ProvideType(dependency.New(func() {}))
+
+		expErr := errors.New("inject: dependency factory should return at least one return type: dependency.Dependency{Factory: func(), Args: []}")
+
+		assert.Error(t, err)
+		assert.Equal(t, expErr, err)
+	})
+
+	t.Run("resolve by type detects cycles", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("a", dependency.New(newUserWithDriver, dependency.Inject("a"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		_, err := ic.Resolve(userType)
+
+		var cycle *types.CycleError
+
+		assert.ErrorAs(t, err, &cycle)
+		assert.ErrorIs(t, err, types.ErrCycle)
+	})
+}
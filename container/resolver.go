@@ -0,0 +1,208 @@
+package container
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/Drafteame/inject/types"
+	"github.com/Drafteame/inject/utils"
+)
+
+// resolver is the view of the container that is handed to dependencies while they are being built. It keeps the
+// symbols that are being resolved on the current build path, so a dependency that needs itself is reported as a cycle
+// instead of recursing forever.
+type resolver struct {
+	ctx       context.Context
+	container *Container
+	path      []types.Symbol
+	from      types.Symbol
+	state     *resolution
+}
+
+// resolution is the state shared by every resolver that takes part on the same top level resolution.
+type resolution struct {
+	cycle    *types.CycleError
+	shared   map[sharedKey]any
+	waiting  *singletonBuild
+	waitPath []types.Symbol
+}
+
+// newResolver creates a resolver with an empty build path, that represents a new top level resolution bound to the
+// given context.
+func (c *Container) newResolver(ctx context.Context) resolver {
+	return resolver{
+		ctx:       ctx,
+		container: c,
+		state:     &resolution{},
+	}
+}
+
+// Context returns the context of the current resolution, so it can be injected on `types.In` structs.
+func (r resolver) Context() context.Context {
+	return r.ctx
+}
+
+// Detach returns a view of the container that resolves lazy dependencies on a new top level resolution when they are
+// called. The dependency that requested the lazy one is kept as requester, so it can still reach the private
+// dependencies of its module.
+func (r resolver) Detach() types.Container {
+	return detached{container: r.container, requester: r.requester()}
+}
+
+// DependencyType returns the type of the instances of the dependency with the given name, without building it, so
+// lazy types are only used when the dependency is not already a function of that type.
+func (r resolver) DependencyType(name types.Symbol) (reflect.Type, bool) {
+	dep, ok := r.container.Lookup(name)
+	if !ok {
+		return nil, false
+	}
+
+	return utils.GetFirstReturnType(dep.Factory), true
+}
+
+// GetContext is the same as Get, but the rest of the build path is resolved with the given context.
+func (r resolver) GetContext(ctx context.Context, name types.Symbol) (any, error) {
+	r.ctx = ctx
+	return r.Get(name)
+}
+
+// Get resolves the dependency associated to the given name, checking first that it is not already being resolved on
+// the current build path.
+func (r resolver) Get(name types.Symbol) (any, error) {
+	c := r.container
+
+	if err := c.checkOpen(); err != nil {
+		return nil, err
+	}
+
+	if err := r.checkCycle(name); err != nil {
+		return nil, err
+	}
+
+	dep, owner, ok := c.lookup(name)
+	if !ok {
+		return nil, &types.NotFoundError{Name: name}
+	}
+
+	if err := c.checkAccess(name, r.requester()); err != nil {
+		return nil, err
+	}
+
+	next := r.push(name)
+
+	switch {
+	case dep.IsSingleton():
+		// Singletons are shared by every scope, so they are built and cached by the container that registered them,
+		// using only the dependencies visible from it.
+		next.container = owner
+		return owner.getSingleton(name, dep, next)
+	case dep.IsScoped():
+		return c.getSingleton(name, dep, next)
+	case isShared(dep):
+		return c.getShared(name, dep, next)
+	default:
+		return c.getInstance(name, dep, next)
+	}
+}
+
+// checkCycle returns a types.CycleError if the symbol is already on the build path. The first cycle found is also saved
+// on the shared resolution state, so it can be returned as is to the caller, no matter how many times it was wrapped
+// by the dependencies that were being built.
+func (r resolver) checkCycle(name types.Symbol) error {
+	for _, symbol := range r.path {
+		if symbol != name {
+			continue
+		}
+
+		cycle := &types.CycleError{Path: append(r.copyPath(), name)}
+
+		if r.state.cycle == nil {
+			r.state.cycle = cycle
+		}
+
+		return cycle
+	}
+
+	return nil
+}
+
+// requester returns the last symbol of the build path, which is the dependency that is requesting the next one. If the
+// build path is empty, it returns the dependency that started the resolution, which is empty unless the resolution was
+// started by a lazy dependency.
+func (r resolver) requester() types.Symbol {
+	if len(r.path) == 0 {
+		return r.from
+	}
+
+	return r.path[len(r.path)-1]
+}
+
+// push returns a new resolver that has the given symbol at the end of its build path.
+func (r resolver) push(name types.Symbol) resolver {
+	r.path = append(r.copyPath(), name)
+	return r
+}
+
+func (r resolver) copyPath() []types.Symbol {
+	path := make([]types.Symbol, len(r.path), len(r.path)+1)
+	copy(path, r.path)
+
+	return path
+}
+
+// err returns the cycle error found during the resolution, if any, or the provided error otherwise.
+func (r resolver) err(err error) error {
+	if r.state.cycle != nil {
+		return r.state.cycle
+	}
+
+	return err
+}
+
+// detached is the container used by lazy dependencies. Each call starts a new top level resolution on behalf of the
+// dependency that requested the lazy one.
+type detached struct {
+	container *Container
+	requester types.Symbol
+}
+
+func (d detached) Get(name types.Symbol) (any, error) {
+	r := d.resolver()
+
+	val, err := r.Get(name)
+	if err != nil {
+		return nil, r.err(err)
+	}
+
+	return val, nil
+}
+
+func (d detached) Resolve(rtype reflect.Type) (any, error) {
+	r := d.resolver()
+
+	val, err := r.Resolve(rtype)
+	if err != nil {
+		return nil, r.err(err)
+	}
+
+	return val, nil
+}
+
+func (d detached) GetGroup(name types.Symbol) ([]any, error) {
+	r := d.resolver()
+
+	vals, err := r.GetGroup(name)
+	if err != nil {
+		return nil, r.err(err)
+	}
+
+	return vals, nil
+}
+
+// resolver returns a new top level resolver that requests dependencies on behalf of the detached requester.
+func (d detached) resolver() resolver {
+	r := d.container.newResolver(context.Background())
+	r.from = d.requester
+
+	return r
+}
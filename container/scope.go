@@ -0,0 +1,66 @@
+package container
+
+import (
+	"github.com/Drafteame/inject/dependency"
+	"github.com/Drafteame/inject/types"
+)
+
+// Scope creates a child container that resolves the dependencies it doesn't have from this container. Dependencies
+// provided on the child shadow the ones of its parents with the same name, and scoped dependencies are cached by the
+// child, so each scope gets its own instance. Singletons are still cached by the container where they were provided.
+//
+// Scopes are useful to model per-request or per-job lifetimes without registering the whole dependency three again. A
+// scope created from a closed container is closed too.
+func (c *Container) Scope(name string) *Container {
+	child := New()
+	child.name = name
+	child.parent = c
+	child.closed = c.checkOpen() != nil
+	child.seen = make(map[*Container]uint64)
+
+	// Dependencies replaced before the scope was created can't have instances on it.
+	for _, parent := range c.chain() {
+		parent.mu.RLock()
+		child.seen[parent] = parent.generation
+		parent.mu.RUnlock()
+	}
+
+	return child
+}
+
+// Name returns the name of the scope represented by the container. The root container has no name.
+func (c *Container) Name() string {
+	return c.name
+}
+
+// Parent returns the container this scope was created from, or nil if it is a root container.
+func (c *Container) Parent() *Container {
+	return c.parent
+}
+
+// lookup finds the dependency of the given name on this container or on the closest parent that provides it, and
+// returns it along with the container that owns it.
+func (c *Container) lookup(name types.Symbol) (dependency.Dependency, *Container, bool) {
+	for _, scope := range c.chain() {
+		scope.mu.RLock()
+		dep, ok := scope.deps[name]
+		scope.mu.RUnlock()
+
+		if ok {
+			return dep, scope, true
+		}
+	}
+
+	return dependency.Dependency{}, nil, false
+}
+
+// chain returns the container followed by all of its parents, up to the root container.
+func (c *Container) chain() []*Container {
+	chain := make([]*Container, 0, 1)
+
+	for scope := c; scope != nil; scope = scope.parent {
+		chain = append(chain, scope)
+	}
+
+	return chain
+}
@@ -0,0 +1,169 @@
+package container
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Drafteame/inject/dependency"
+)
+
+func TestContainer_Scope(t *testing.T) {
+	t.Run("resolve parent dependencies from child", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("user", dependency.New(newUser, "John", 21)); err != nil {
+			t.Error(err)
+			return
+		}
+
+		child := ic.Scope("request")
+
+		assert.Equal(t, "request", child.Name())
+		assert.Same(t, ic, child.Parent())
+
+		val, err := child.Get("user")
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, "John", val.(*user).getName())
+		}
+	})
+
+	t.Run("child registrations shadow parent ones", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("driver", dependency.New(newDriver, "parent")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("user", dependency.New(newUserWithDriver, dependency.Inject("driver"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		child := ic.Scope("request")
+
+		if err := child.Provide("driver", dependency.New(newDriver, "child")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		val, err := child.Get("user")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "child", val.(*user).getDb().client())
+		}
+
+		val, err = ic.Get("user")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "parent", val.(*user).getDb().client())
+		}
+
+		val, err = child.Resolve(reflect.TypeOf(&driver{}))
+		if assert.NoError(t, err) {
+			assert.Equal(t, "child", val.(*driver).client())
+		}
+	})
+
+	t.Run("scoped dependencies are cached by each scope", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("driver", dependency.NewScoped(newDriver, "main")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		first := ic.Scope("first")
+		second := ic.Scope("second")
+
+		a1, err := first.Get("driver")
+		assert.NoError(t, err)
+
+		a2, err := first.Get("driver")
+		assert.NoError(t, err)
+
+		b1, err := second.Get("driver")
+		assert.NoError(t, err)
+
+		root, err := ic.Get("driver")
+		assert.NoError(t, err)
+
+		assert.Same(t, a1, a2)
+		assert.NotSame(t, a1, b1)
+		assert.NotSame(t, a1, root)
+		assert.Len(t, first.solvedDeps, 1)
+	})
+
+	t.Run("singletons are shared by every scope", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("driver", dependency.NewSingleton(newDriver, "main")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		a, err := ic.Scope("first").Get("driver")
+		assert.NoError(t, err)
+
+		b, err := ic.Scope("second").Get("driver")
+		assert.NoError(t, err)
+
+		assert.Same(t, a, b)
+		assert.Len(t, ic.solvedDeps, 1)
+	})
+
+	t.Run("parent singletons do not use child registrations", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("driver", dependency.New(newDriver, "parent")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("user", dependency.NewSingleton(newUserWithDriver, dependency.Inject("driver"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		child := ic.Scope("request")
+
+		if err := child.Provide("driver", dependency.New(newDriver, "child")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		val, err := child.Get("user")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "parent", val.(*user).getDb().client())
+		}
+	})
+
+	t.Run("groups include members of every scope", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("john", dependency.New(newUser, "John", 21).InGroup("users")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		child := ic.Scope("request")
+
+		if err := child.Provide("jane", dependency.New(newUser, "Jane", 22).InGroup("users")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		vals, err := child.GetGroup("users")
+
+		if assert.NoError(t, err) && assert.Len(t, vals, 2) {
+			assert.Equal(t, "John", vals[0].(*user).getName())
+			assert.Equal(t, "Jane", vals[1].(*user).getName())
+		}
+
+		vals, err = ic.GetGroup("users")
+
+		assert.NoError(t, err)
+		assert.Len(t, vals, 1)
+	})
+}
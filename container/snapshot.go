@@ -0,0 +1,92 @@
+package container
+
+import (
+	"reflect"
+
+	"github.com/Drafteame/inject/dependency"
+	"github.com/Drafteame/inject/types"
+)
+
+// Snapshot is a copy of the registrations and cached instances of a container at some point in time, that can be
+// restored later.
+type Snapshot struct {
+	solvedDeps map[types.Symbol]any
+	deps       map[types.Symbol]dependency.Dependency
+	byType     map[reflect.Type][]types.Symbol
+	groups     map[types.Symbol][]types.Symbol
+	decorators map[types.Symbol][]decorator
+	members    map[types.Symbol]membership
+	sources    map[types.Symbol]reflect.Type
+	built      []types.Symbol
+}
+
+// Snapshot saves the current registrations and cached instances of the container, so they can be restored after
+// overriding some dependencies on a test, e.g:
+//
+//	snap := c.Snapshot()
+//	t.Cleanup(func() { c.Restore(snap) })
+//
+//	_ = c.Replace("mailer", dependency.New(newMailerMock))
+func (c *Container) Snapshot() Snapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return Snapshot{
+		solvedDeps: copyMap(c.solvedDeps),
+		deps:       copyMap(c.deps),
+		byType:     copyTypeIndex(c.byType),
+		groups:     copySliceMap(c.groups),
+		decorators: copySliceMap(c.decorators),
+		members:    copyMap(c.members),
+		sources:    copyMap(c.sources),
+		built:      append([]types.Symbol{}, c.built...),
+	}
+}
+
+// Restore sets back the registrations and cached instances saved on the snapshot. Instances built after the snapshot
+// was taken are dropped without being closed.
+func (c *Container) Restore(s Snapshot) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.solvedDeps = copyMap(s.solvedDeps)
+	c.deps = copyMap(s.deps)
+	c.byType = copyTypeIndex(s.byType)
+	c.groups = copySliceMap(s.groups)
+	c.decorators = copySliceMap(s.decorators)
+	c.members = copyMap(s.members)
+	c.sources = copyMap(s.sources)
+	c.built = append([]types.Symbol{}, s.built...)
+	c.inflight = make(map[types.Symbol]*singletonBuild)
+}
+
+func copyMap[K comparable, V any](m map[K]V) map[K]V {
+	res := make(map[K]V, len(m))
+
+	for k, v := range m {
+		res[k] = v
+	}
+
+	return res
+}
+
+func copySliceMap[K comparable, V any](m map[K][]V) map[K][]V {
+	res := make(map[K][]V, len(m))
+
+	for k, v := range m {
+		res[k] = append([]V{}, v...)
+	}
+
+	return res
+}
+
+// copyTypeIndex is the same as copySliceMap, since interface keys are only comparable as type parameters from go 1.20.
+func copyTypeIndex(m map[reflect.Type][]types.Symbol) map[reflect.Type][]types.Symbol {
+	res := make(map[reflect.Type][]types.Symbol, len(m))
+
+	for k, v := range m {
+		res[k] = append([]types.Symbol{}, v...)
+	}
+
+	return res
+}
@@ -0,0 +1,139 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/Drafteame/inject/dependency"
+	"github.com/Drafteame/inject/types"
+)
+
+// Validate checks the whole dependency three of the container without calling any factory. Every provided dependency
+// and its nested arguments are checked against the signature of its factory, every injected name should be provided,
+// and there should be no dependency cycles. All the problems found are returned at once as a types.Errors value.
+//
+// Dependencies provided on parent containers are also checked when the container is a scope.
+func (c *Container) Validate() error {
+	if err := c.checkOpen(); err != nil {
+		return err
+	}
+
+	errs := make(types.Errors, 0)
+	names := c.visibleSymbols()
+
+	for _, name := range names {
+		dep, _, _ := c.lookup(name)
+
+		if err := dep.Validate(c); err != nil {
+			errs = append(errs, fmt.Errorf("inject: invalid dependency `%s`: %w", name, err))
+		}
+
+		for _, ref := range dep.References() {
+			if err := c.checkAccess(ref, name); err != nil {
+				errs = append(errs, fmt.Errorf("inject: invalid dependency `%s`: %w", name, err))
+			}
+		}
+
+		for _, d := range c.decoratorsOf(name) {
+			if err := d.dependency(reflect.TypeOf(d.fn).In(0), nil).Validate(c); err != nil {
+				errs = append(errs, fmt.Errorf("inject: invalid decorator for `%s`: %w", name, err))
+			}
+		}
+	}
+
+	for _, cycle := range c.findCycles(names) {
+		errs = append(errs, cycle)
+	}
+
+	return errs.ErrOrNil()
+}
+
+// Lookup returns the dependency provided with the given name on the container or any of its parents, without building
+// it.
+func (c *Container) Lookup(name types.Symbol) (dependency.Dependency, bool) {
+	dep, _, ok := c.lookup(name)
+	return dep, ok
+}
+
+// visibleSymbols returns the sorted names of every dependency that can be resolved from the container.
+func (c *Container) visibleSymbols() []types.Symbol {
+	names := make([]types.Symbol, 0)
+
+	for _, scope := range c.chain() {
+		scope.mu.RLock()
+
+		for name := range scope.deps {
+			if !containsSymbol(names, name) {
+				names = append(names, name)
+			}
+		}
+
+		scope.mu.RUnlock()
+	}
+
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+
+	return names
+}
+
+// findCycles walks the references between dependencies looking for cycles. Each cycle is reported once, starting on
+// the first symbol of the walk that takes part on it.
+func (c *Container) findCycles(names []types.Symbol) []*types.CycleError {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+
+	state := make(map[types.Symbol]int)
+	cycles := make([]*types.CycleError, 0)
+	path := make([]types.Symbol, 0)
+
+	var visit func(name types.Symbol)
+
+	visit = func(name types.Symbol) {
+		dep, ok := c.Lookup(name)
+		if !ok {
+			return
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+
+		for _, ref := range dep.EagerReferences() {
+			switch state[ref] {
+			case visiting:
+				cycles = append(cycles, &types.CycleError{Path: cyclePath(path, ref)})
+			case 0:
+				visit(ref)
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[name] = visited
+	}
+
+	for _, name := range names {
+		if state[name] == 0 {
+			visit(name)
+		}
+	}
+
+	return cycles
+}
+
+// cyclePath returns the part of the path that starts on the given symbol, closed by the symbol itself.
+func cyclePath(path []types.Symbol, name types.Symbol) []types.Symbol {
+	for i := range path {
+		if path[i] != name {
+			continue
+		}
+
+		cycle := make([]types.Symbol, 0, len(path)-i+1)
+		cycle = append(cycle, path[i:]...)
+
+		return append(cycle, name)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package container
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Drafteame/inject/dependency"
+	"github.com/Drafteame/inject/types"
+)
+
+func TestContainer_Validate(t *testing.T) {
+	t.Run("valid dependency three", func(t *testing.T) {
+		ic := New()
+		called := false
+
+		factory := func(name string) *driver {
+			called = true
+			return newDriver(name)
+		}
+
+		if err := ic.Provide("driver", dependency.NewSingleton(factory, "main")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("user", dependency.New(newUserWithDriver, dependency.Inject("driver"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("todo", dependency.New(newTodo, dependency.New(newDriver, func() string { return "aux" }))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		assert.NoError(t, ic.Validate())
+		assert.False(t, called)
+	})
+
+	t.Run("report every problem at once", func(t *testing.T) {
+		ic := New()
+
+		deps := map[types.Symbol]dependency.Dependency{
+			"a": dependency.New(newUserWithDriver, dependency.Inject("missing")),
+			"b": dependency.New(newUser, 21, "John"),
+			"c": dependency.New(newUser, "John"),
+			"d": dependency.New(newTodo, dependency.New(newUser, "John", 21)),
+			"e": dependency.New(newUserWithDriver, dependency.Inject("f")),
+			"f": dependency.New(newDriver, dependency.New(func(*user) string { return "" }, dependency.Inject("e"))),
+		}
+
+		for name, dep := range deps {
+			if err := ic.Provide(name, dep); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		err := ic.Validate()
+
+		var errs types.Errors
+
+		if !assert.ErrorAs(t, err, &errs) {
+			return
+		}
+
+		msgs := make([]string, len(errs))
+
+		for i, e := range errs {
+			msgs[i] = e.Error()
+		}
+
+		expected := []string{
+			"inject: invalid dependency `a`: inject: error resolving argument 0 for constructor func(container.database) *container.user: inject: no provided dependency of name `missing`",
+			"inject: invalid dependency `b`: inject: 2 errors occurred: inject: using int as type string on constructor `func(string, int) *container.user`; inject: using string as type int on constructor `func(string, int) *container.user`",
+			"inject: invalid dependency `c`: inject: invalid argument length for constructor `func(string, int) *container.user`, got 1 (need 2)",
+			"inject: invalid dependency `d`: inject: using *container.user as type container.database on constructor `func(container.database) *container.todo`",
+			"inject: dependency cycle detected: e -> f -> e",
+		}
+
+		assert.Equal(t, expected, msgs)
+
+		var cycle *types.CycleError
+
+		assert.True(t, errors.As(err, &cycle))
+	})
+
+	t.Run("lazy dependencies break cycles", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("a", dependency.New(func(b types.Lazy[*driver]) *user { return &user{} }, dependency.Inject("b"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ic.Provide("b", dependency.New(func(u *user) *driver { return newDriver("main") }, dependency.Inject("a"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		assert.NoError(t, ic.Validate())
+	})
+
+	t.Run("validate scope with parent dependencies", func(t *testing.T) {
+		ic := New()
+
+		if err := ic.Provide("driver", dependency.New(newDriver, "main")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		child := ic.Scope("request")
+
+		if err := child.Provide("user", dependency.New(newUserWithDriver, dependency.Inject("driver"))); err != nil {
+			t.Error(err)
+			return
+		}
+
+		assert.NoError(t, child.Validate())
+	})
+}
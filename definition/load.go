@@ -0,0 +1,324 @@
+package definition
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Drafteame/inject/container"
+	"github.com/Drafteame/inject/dependency"
+	"github.com/Drafteame/inject/types"
+)
+
+// Provider is the container where the loaded dependencies are provided. `*container.Container`, and the containers
+// returned by the `inject` package, satisfy it.
+type Provider interface {
+	Provide(name types.Symbol, dep dependency.Dependency, opts ...container.ProvideOption) error
+}
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// definition is a dependency read from a definition file, ready to be provided.
+type definition struct {
+	name types.Symbol
+	node *yaml.Node
+	dep  dependency.Dependency
+}
+
+// LoadFile reads the JSON or YAML definition file on the given path and provides its dependencies on the container. See
+// Load for the format of the file.
+func (r *Registry) LoadFile(p Provider, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("inject: can't read definition file: %w", err)
+	}
+
+	return r.load(p, path, data)
+}
+
+// Load reads a JSON or YAML definition and provides its dependencies on the container. The definition lists each
+// dependency under the `dependencies` key, with the name of the registered factory that builds it:
+//
+//	dependencies:
+//	  mailer:
+//	    factory: mailer.smtp
+//	    singleton: true
+//	    description: outgoing mail
+//	    groups: [notifiers]
+//	    args:
+//	      - smtp.example.com
+//	      - env: SMTP_PORT
+//	        default: 25
+//	      - inject: config.credentials
+//
+// Each argument is either a literal value, decoded as the type of the factory parameter, an `inject` reference to other
+// dependency, or an `env` variable with an optional `default` value, read when the dependency is built. A factory that
+// receives a context.Context as first parameter gets it from the container, so it is not listed on the arguments.
+//
+// Every dependency is checked before any of them is provided, and the problems found are returned as a types.Errors
+// value of types.DefinitionError values, that point at the line and key of each problem. Dependencies are provided in
+// the order they are defined, and loading stops on the first one that can't be provided.
+func (r *Registry) Load(p Provider, data []byte) error {
+	return r.load(p, "", data)
+}
+
+func (r *Registry) load(p Provider, file string, data []byte) error {
+	var root yaml.Node
+
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("inject: can't parse definition file: %w", err)
+	}
+
+	if len(root.Content) == 0 {
+		return nil
+	}
+
+	defs, errs := r.parse(root.Content[0])
+
+	for i := range errs {
+		errs[i].(*types.DefinitionError).File = file
+	}
+
+	if err := types.Errors(errs).ErrOrNil(); err != nil {
+		return err
+	}
+
+	for _, def := range defs {
+		if err := p.Provide(def.name, def.dep); err != nil {
+			return &types.DefinitionError{File: file, Line: def.node.Line, Key: "dependencies." + string(def.name), Err: err}
+		}
+	}
+
+	return nil
+}
+
+// parse reads every dependency of the definition document.
+func (r *Registry) parse(doc *yaml.Node) ([]definition, []error) {
+	if doc.Kind != yaml.MappingNode {
+		return nil, []error{keyError(doc, "", fmt.Errorf("definition should be a mapping"))}
+	}
+
+	defs := make([]definition, 0)
+	errs := make([]error, 0)
+
+	for i := 0; i < len(doc.Content); i += 2 {
+		key, value := doc.Content[i], doc.Content[i+1]
+
+		if key.Value != "dependencies" {
+			errs = append(errs, keyError(key, key.Value, fmt.Errorf("unknown key")))
+			continue
+		}
+
+		if value.Kind != yaml.MappingNode {
+			errs = append(errs, keyError(value, key.Value, fmt.Errorf("should be a mapping of dependency names")))
+			continue
+		}
+
+		for j := 0; j < len(value.Content); j += 2 {
+			name, node := value.Content[j], value.Content[j+1]
+
+			dep, depErrs := r.parseDependency("dependencies."+name.Value, node)
+			if len(depErrs) > 0 {
+				errs = append(errs, depErrs...)
+				continue
+			}
+
+			defs = append(defs, definition{name: types.Symbol(name.Value), node: name, dep: dep})
+		}
+	}
+
+	return defs, errs
+}
+
+// parseDependency reads the definition of a single dependency, found on the given key.
+func (r *Registry) parseDependency(path string, node *yaml.Node) (dependency.Dependency, []error) {
+	if node.Kind != yaml.MappingNode {
+		return dependency.Dependency{}, []error{keyError(node, path, fmt.Errorf("dependency should be a mapping"))}
+	}
+
+	var factoryNode, argsNode *yaml.Node
+	var singleton bool
+	var description string
+	var groups []types.Symbol
+
+	errs := make([]error, 0)
+
+	for i := 0; i < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		kpath := path + "." + key.Value
+
+		var err error
+
+		switch key.Value {
+		case "factory":
+			factoryNode = value
+		case "args":
+			argsNode = value
+		case "singleton":
+			err = decode(value, &singleton, "a boolean")
+		case "description":
+			err = decode(value, &description, "a string")
+		case "groups":
+			err = decode(value, &groups, "a list of group names")
+		default:
+			err = fmt.Errorf("unknown key")
+			value = key
+		}
+
+		if err != nil {
+			errs = append(errs, keyError(value, kpath, err))
+		}
+	}
+
+	if factoryNode == nil {
+		return dependency.Dependency{}, append(errs, keyError(node, path, fmt.Errorf("missing `factory` key")))
+	}
+
+	var name string
+
+	if err := decode(factoryNode, &name, "a factory name"); err != nil {
+		return dependency.Dependency{}, append(errs, keyError(factoryNode, path+".factory", err))
+	}
+
+	factory, ok := r.Factory(name)
+	if !ok {
+		return dependency.Dependency{}, append(errs, keyError(factoryNode, path+".factory", fmt.Errorf("unknown factory `%s`", name)))
+	}
+
+	args, argErrs := parseArgs(path+".args", node, argsNode, reflect.TypeOf(factory))
+	if errs = append(errs, argErrs...); len(errs) > 0 {
+		return dependency.Dependency{}, errs
+	}
+
+	dep := dependency.New(factory, args...)
+	dep.Singleton = singleton
+	dep.Description = description
+
+	return dep.InGroup(groups...), nil
+}
+
+// parseArgs reads the arguments of a dependency, checking them against the parameters of the factory.
+func parseArgs(path string, parent, node *yaml.Node, ftype reflect.Type) ([]any, []error) {
+	nodes := make([]*yaml.Node, 0)
+
+	if node != nil {
+		if node.Kind != yaml.SequenceNode {
+			return nil, []error{keyError(node, path, fmt.Errorf("should be a list of arguments"))}
+		}
+
+		nodes = node.Content
+	} else {
+		node = parent
+	}
+
+	offset := 0
+
+	if ftype.NumIn() > 0 && ftype.In(0) == contextType && len(nodes) == ftype.NumIn()-1 {
+		offset = 1
+	}
+
+	if len(nodes)+offset != ftype.NumIn() {
+		return nil, []error{keyError(node, path, fmt.Errorf("factory `%v` receives %d arguments, got %d", ftype, ftype.NumIn(), len(nodes)))}
+	}
+
+	args := make([]any, len(nodes))
+	errs := make([]error, 0)
+
+	for i, arg := range nodes {
+		val, err := parseArg(fmt.Sprintf("%s[%d]", path, i), arg, ftype.In(i+offset))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		args[i] = val
+	}
+
+	return args, errs
+}
+
+// parseArg reads an argument of a dependency, that is passed to a factory parameter of type `targ`.
+func parseArg(path string, node *yaml.Node, targ reflect.Type) (any, error) {
+	if node.Kind == yaml.MappingNode && len(node.Content) > 0 {
+		switch node.Content[0].Value {
+		case "inject":
+			return parseInject(path, node)
+		case "env":
+			return parseEnv(path, node, targ)
+		}
+	}
+
+	val := reflect.New(targ)
+
+	if err := node.Decode(val.Interface()); err != nil {
+		return nil, keyError(node, path, fmt.Errorf("can't use value as %v", targ))
+	}
+
+	return val.Elem().Interface(), nil
+}
+
+// parseInject reads an argument that references other dependency, like `{inject: name}`.
+func parseInject(path string, node *yaml.Node) (any, error) {
+	if len(node.Content) != 2 {
+		return nil, keyError(node, path, fmt.Errorf("`inject` reference can't have other keys"))
+	}
+
+	var name string
+
+	if err := decode(node.Content[1], &name, "a dependency name"); err != nil || name == "" {
+		return nil, keyError(node.Content[1], path+".inject", fmt.Errorf("should be a dependency name"))
+	}
+
+	return dependency.Inject(types.Symbol(name)), nil
+}
+
+// parseEnv reads an argument that is taken from an environment variable, like `{env: NAME, default: value}`. The
+// default value is decoded as the parameter type.
+func parseEnv(path string, node *yaml.Node, targ reflect.Type) (any, error) {
+	var name string
+	var def *yaml.Node
+
+	for i := 0; i < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		switch key.Value {
+		case "env":
+			if err := decode(value, &name, "a variable name"); err != nil || name == "" {
+				return nil, keyError(value, path+".env", fmt.Errorf("should be a variable name"))
+			}
+		case "default":
+			def = value
+		default:
+			return nil, keyError(key, path+"."+key.Value, fmt.Errorf("unknown key"))
+		}
+	}
+
+	if def == nil {
+		return dependency.Env(name), nil
+	}
+
+	val := reflect.New(targ)
+
+	if err := def.Decode(val.Interface()); err != nil {
+		return nil, keyError(def, path+".default", fmt.Errorf("can't use value as %v", targ))
+	}
+
+	return dependency.EnvOr(name, val.Elem().Interface()), nil
+}
+
+// decode decodes the node into `out`, returning an error that describes the expected value if it can't.
+func decode(node *yaml.Node, out any, expected string) error {
+	if err := node.Decode(out); err != nil {
+		return fmt.Errorf("should be %s", expected)
+	}
+
+	return nil
+}
+
+// keyError returns a types.DefinitionError that points at the line of the node.
+func keyError(node *yaml.Node, key string, err error) error {
+	return &types.DefinitionError{Line: node.Line, Key: key, Err: err}
+}
@@ -0,0 +1,173 @@
+package definition
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Drafteame/inject/container"
+	"github.com/Drafteame/inject/dependency"
+	"github.com/Drafteame/inject/types"
+)
+
+type mailer interface {
+	Send(to string) string
+}
+
+type smtpMailer struct {
+	host    string
+	port    int
+	timeout time.Duration
+}
+
+func (m *smtpMailer) Send(to string) string {
+	return "smtp " + to
+}
+
+type mockMailer struct {
+	sent []string
+}
+
+func (m *mockMailer) Send(to string) string {
+	return "mock " + to
+}
+
+func newRegistry(t *testing.T) *Registry {
+	reg := NewRegistry()
+
+	factories := map[string]any{
+		"host": func(host string) string { return host },
+		"mailer.smtp": func(host string, port int, timeout time.Duration) mailer {
+			return &smtpMailer{host: host, port: port, timeout: timeout}
+		},
+		"mailer.mock": func(sent []string) mailer { return &mockMailer{sent: sent} },
+	}
+
+	for name, factory := range factories {
+		if err := reg.Register(name, factory); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return reg
+}
+
+func TestRegistry_LoadFile(t *testing.T) {
+	t.Run("load yaml definition", func(t *testing.T) {
+		t.Setenv("INJECT_TEST_SMTP_PORT", "2525")
+
+		ic := container.New()
+
+		if err := newRegistry(t).LoadFile(ic, "testdata/deps.yaml"); err != nil {
+			t.Error(err)
+			return
+		}
+
+		val, err := ic.Get("mailer")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &smtpMailer{host: "smtp.example.com", port: 2525, timeout: 10 * time.Second}, val)
+
+		again, err := ic.Get("mailer")
+
+		assert.NoError(t, err)
+		assert.Same(t, val, again)
+
+		members, err := ic.GetGroup("notifiers")
+
+		assert.NoError(t, err)
+		assert.Len(t, members, 1)
+	})
+
+	t.Run("load json definition", func(t *testing.T) {
+		ic := container.New()
+
+		if err := newRegistry(t).LoadFile(ic, "testdata/deps.json"); err != nil {
+			t.Error(err)
+			return
+		}
+
+		val, err := ic.Get("mailer")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &mockMailer{sent: []string{"welcome"}}, val)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		err := newRegistry(t).LoadFile(container.New(), "testdata/missing.yaml")
+
+		assert.ErrorContains(t, err, "inject: can't read definition file")
+	})
+}
+
+func TestRegistry_Load(t *testing.T) {
+	t.Run("point at line and key of every problem", func(t *testing.T) {
+		data := []byte(`dependencies:
+  mailer:
+    factory: mailer.smtp
+    singleton: maybe
+    args:
+      - smtp.example.com
+      - not a port
+      - 10s
+  other:
+    factory: mailer.unknown
+  broken:
+    args: []
+    retries: 3
+`)
+
+		ic := container.New()
+
+		err := newRegistry(t).Load(ic, data)
+
+		assert.EqualError(t, err, "inject: 5 errors occurred: "+
+			"inject: line 4: error on key `dependencies.mailer.singleton`: should be a boolean; "+
+			"inject: line 7: error on key `dependencies.mailer.args[1]`: can't use value as int; "+
+			"inject: line 10: error on key `dependencies.other.factory`: unknown factory `mailer.unknown`; "+
+			"inject: line 13: error on key `dependencies.broken.retries`: unknown key; "+
+			"inject: line 12: error on key `dependencies.broken`: missing `factory` key")
+
+		var defErr *types.DefinitionError
+
+		assert.True(t, errors.As(err, &defErr))
+		assert.Equal(t, 4, defErr.Line)
+
+		_, ok := ic.Lookup("mailer")
+
+		assert.False(t, ok)
+	})
+
+	t.Run("wrong number of arguments", func(t *testing.T) {
+		data := []byte(`dependencies:
+  mailer:
+    factory: mailer.mock
+`)
+
+		err := newRegistry(t).Load(container.New(), data)
+
+		assert.EqualError(t, err, "inject: line 3: error on key `dependencies.mailer.args`: factory `func([]string) definition.mailer` receives 1 arguments, got 0")
+	})
+
+	t.Run("duplicated dependency", func(t *testing.T) {
+		ic := container.New()
+
+		if err := ic.Provide("mailer", dependency.New(func() mailer { return &mockMailer{} })); err != nil {
+			t.Error(err)
+			return
+		}
+
+		err := newRegistry(t).Load(ic, []byte(`{"dependencies": {"mailer": {"factory": "mailer.mock", "args": [[]]}}}`))
+
+		assert.ErrorIs(t, err, types.ErrDuplicate)
+		assert.ErrorContains(t, err, "inject: line 1: error on key `dependencies.mailer`")
+	})
+
+	t.Run("invalid document", func(t *testing.T) {
+		err := newRegistry(t).Load(container.New(), []byte(`- mailer`))
+
+		assert.EqualError(t, err, "inject: line 1: definition should be a mapping")
+	})
+}
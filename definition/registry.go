@@ -0,0 +1,55 @@
+package definition
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Registry holds the factories that can be referenced by name from a definition file. Go code registers every factory
+// that may be used, and the definition file chooses which of them builds each dependency, so implementations can be
+// switched without recompiling.
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]any
+}
+
+// NewRegistry returns an empty factory registry.
+func NewRegistry() *Registry {
+	return &Registry{factories: make(map[string]any)}
+}
+
+// Register adds the factory to the registry with the given name. The factory should be a function that returns at
+// least one value, and the name can't be already registered.
+func (r *Registry) Register(name string, factory any) error {
+	if name == "" {
+		return fmt.Errorf("inject: factory name cannot be empty")
+	}
+
+	ftype := reflect.TypeOf(factory)
+
+	if ftype == nil || ftype.Kind() != reflect.Func || ftype.NumOut() == 0 {
+		return fmt.Errorf("inject: factory `%s` should be a function that returns at least one value, got `%v`", name, ftype)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.factories[name]; ok {
+		return fmt.Errorf("inject: factory `%s` is already registered", name)
+	}
+
+	r.factories[name] = factory
+
+	return nil
+}
+
+// Factory returns the factory registered with the given name, and false if there is none.
+func (r *Registry) Factory(name string) (any, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	factory, ok := r.factories[name]
+
+	return factory, ok
+}
@@ -0,0 +1,41 @@
+package definition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Register(t *testing.T) {
+	t.Run("register factory", func(t *testing.T) {
+		reg := NewRegistry()
+
+		assert.NoError(t, reg.Register("host", func() string { return "localhost" }))
+
+		_, ok := reg.Factory("host")
+
+		assert.True(t, ok)
+
+		_, ok = reg.Factory("other")
+
+		assert.False(t, ok)
+	})
+
+	t.Run("duplicated factory", func(t *testing.T) {
+		reg := NewRegistry()
+
+		assert.NoError(t, reg.Register("host", func() string { return "localhost" }))
+
+		err := reg.Register("host", func() string { return "other" })
+
+		assert.EqualError(t, err, "inject: factory `host` is already registered")
+	})
+
+	t.Run("invalid factory", func(t *testing.T) {
+		reg := NewRegistry()
+
+		err := reg.Register("host", "localhost")
+
+		assert.EqualError(t, err, "inject: factory `host` should be a function that returns at least one value, got `string`")
+	})
+}
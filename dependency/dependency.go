@@ -1,6 +1,7 @@
 package dependency
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -16,17 +17,40 @@ type Builder interface {
 	Build() (any, error)
 }
 
+// ContextBuilder is a Builder that can receive the context of the current build.
+type ContextBuilder interface {
+	Builder
+	BuildContext(ctx context.Context) (any, error)
+}
+
 // Container is a container that holds global dependencies.
 type Container interface {
 	Get(name types.Symbol) (any, error)
 }
 
+// ContextContainer is a Container that can receive the context of the current build.
+type ContextContainer interface {
+	Container
+	GetContext(ctx context.Context, name types.Symbol) (any, error)
+}
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
 // Dependency implementation of dependency.
 type Dependency struct {
-	Factory   any
-	Args      []any
-	Singleton bool
-	container Container
+	Factory     any
+	Args        []any
+	Singleton   bool
+	Scoped      bool
+	Interfaces  []any
+	Groups      []types.Symbol
+	Description string
+	OnClose     func(ctx context.Context, instance any) error
+	OutputNames []types.Symbol
+	container   Container
 }
 
 // New Create a new Dependency struct to build injection. Factory is a function with one of the next
@@ -37,6 +61,9 @@ type Dependency struct {
 //   - func(any-arguments)
 //   - func(any-arguments) any
 //   - func(any-arguments) (any, error)
+//
+// If the first parameter of the factory is a context.Context that is not provided on the arguments, it will receive
+// the context of the build.
 func New(constructor any, args ...any) Dependency {
 	return Dependency{
 		Factory: constructor,
@@ -53,9 +80,50 @@ func NewSingleton(constructor any, args ...any) Dependency {
 	}
 }
 
+// NewScoped Create a new Dependency struct to build injection but marking that will be shared inside each scope. Every
+// scope created from the container, and the container itself, will build and keep its own instance.
+func NewScoped(constructor any, args ...any) Dependency {
+	return Dependency{
+		Factory: constructor,
+		Args:    args,
+		Scoped:  true,
+	}
+}
+
 // IsSingleton returns true if the current dependency will be treated as a shared dependency.
 func (d Dependency) IsSingleton() bool { return d.Singleton }
 
+// IsScoped returns true if the current dependency will be treated as a shared dependency inside each scope.
+func (d Dependency) IsScoped() bool { return d.Scoped && !d.Singleton }
+
+// As returns a copy of the dependency that is also bound to the interfaces pointed by the provided values, e.g:
+//
+// dependency.New(newDriver, "main").As(new(database), new(io.Closer))
+//
+// The container checks that the factory return type implements those interfaces when the dependency is provided, and
+// makes it resolvable by each of them.
+func (d Dependency) As(ifaces ...any) Dependency {
+	interfaces := make([]any, 0, len(d.Interfaces)+len(ifaces))
+	interfaces = append(interfaces, d.Interfaces...)
+	interfaces = append(interfaces, ifaces...)
+
+	d.Interfaces = interfaces
+
+	return d
+}
+
+// InGroup returns a copy of the dependency that is also a member of the provided value groups. Every member of a group
+// can be injected at once as a slice, on a `types.In` field tagged with the `group` option.
+func (d Dependency) InGroup(groups ...types.Symbol) Dependency {
+	names := make([]types.Symbol, 0, len(d.Groups)+len(groups))
+	names = append(names, d.Groups...)
+	names = append(names, groups...)
+
+	d.Groups = names
+
+	return d
+}
+
 // SetContainer add shared container to the dependency object in order to resolve shared arguments in the
 // dependency three.
 func (d Dependency) SetContainer(sc Container) Dependency {
@@ -67,24 +135,39 @@ func (d Dependency) SetContainer(sc Container) Dependency {
 // constructor with those arguments using reflection (`reflect` package). Finally, it returns a value and an error if
 // any of them is not nil (the error can be returned by one of the dependencies).
 func (d Dependency) Build() (any, error) {
+	return d.BuildContext(context.Background())
+}
+
+// BuildContext is the same as Build, but the context is passed to every factory of the dependency three that receives
+// a context.Context as first parameter, and to the container when injected arguments are resolved. If the context is
+// done before a factory is called, the build stops and returns the context error.
+func (d Dependency) BuildContext(ctx context.Context) (any, error) {
 	ctype, err := d.validateAndGetReflectType()
 	if err != nil {
 		return nil, err
 	}
 
-	args, err := d.getArgsValues(ctype)
+	args, err := d.getArgsValues(ctx, ctype)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("inject: context done before constructing `%v`: %w", ctype, err)
+	}
+
 	res := reflect.ValueOf(d.Factory).Call(args)
 
-	arg, err := d.getValueAndError(res)
+	vals, err := d.getValuesAndError(ctype, res)
 	if err != nil {
-		return nil, fmt.Errorf("inject: error constructing `%v`: %v", ctype, err)
+		return nil, fmt.Errorf("inject: error constructing `%v`: %w", ctype, err)
 	}
 
-	return arg, nil
+	if len(vals) == 0 {
+		return nil, nil
+	}
+
+	return vals[0], nil
 }
 
 func (d Dependency) String() string {
@@ -106,6 +189,10 @@ func (d Dependency) validateAndGetReflectType() (reflect.Type, error) {
 	}
 
 	argsLen := ctype.NumIn()
+	if d.injectsContext(ctype) {
+		argsLen--
+	}
+
 	if argsLen != len(d.Args) {
 		return nil, fmt.Errorf("inject: invalid argument length for constructor `%v`, got %v (need %v)", ctype, len(d.Args), argsLen)
 	}
@@ -118,19 +205,23 @@ func (d Dependency) validateAndGetReflectType() (reflect.Type, error) {
 // by the constructor (the type is taken from ctype). If they are not assignable, then an error is returned, otherwise
 // it adds them to values slice as reflect.Value objects and returns them at last along with nil error value
 // (if everything went well).
-func (d Dependency) getArgsValues(ctype reflect.Type) ([]reflect.Value, error) {
-	args, err := d.resolveArguments(ctype)
+func (d Dependency) getArgsValues(ctx context.Context, ctype reflect.Type) ([]reflect.Value, error) {
+	args, err := d.resolveArguments(ctx, ctype)
 	if err != nil {
 		return nil, err
 	}
 
-	values := make([]reflect.Value, len(args))
+	values := make([]reflect.Value, 0, ctype.NumIn())
+
+	if d.injectsContext(ctype) {
+		values = append(values, reflect.ValueOf(&ctx).Elem())
+	}
 
 	for i := 0; i < len(args); i++ {
-		targ := ctype.In(i)
+		targ := d.paramType(ctype, i)
 
 		if (targ.Kind() == reflect.Interface || targ.Kind() == reflect.Ptr) && args[i] == nil {
-			values[i] = reflect.Zero(targ)
+			values = append(values, reflect.Zero(targ))
 			continue
 		}
 
@@ -140,18 +231,34 @@ func (d Dependency) getArgsValues(ctype reflect.Type) ([]reflect.Value, error) {
 			return nil, fmt.Errorf("inject: using %s as type %s on constructor `%v`", xt.String(), targ.String(), ctype)
 		}
 
-		values[i] = reflect.ValueOf(args[i])
+		values = append(values, reflect.ValueOf(args[i]))
 	}
 
 	return values, nil
 }
 
+// injectsContext returns true if the factory receives a context.Context as first parameter that is not provided on
+// the arguments, so it should receive the context of the build.
+func (d Dependency) injectsContext(ctype reflect.Type) bool {
+	return ctype.NumIn() == len(d.Args)+1 && ctype.In(0) == contextType
+}
+
+// paramType returns the type of the factory parameter that receives the argument on the given index.
+func (d Dependency) paramType(ctype reflect.Type, index int) reflect.Type {
+	if d.injectsContext(ctype) {
+		return ctype.In(index + 1)
+	}
+
+	return ctype.In(index)
+}
+
 // resolveArguments It creates a slice of `any` type with the length of the number of arguments. For each argument, it
 // normalizes it and builds it using the builder. If there is an error, we return an error message that contains
 // information about which argument failed and what constructor was used (we will see how this works in a moment).
 // Otherwise, we add the result to our slice and continue with the next argument until all arguments are resolved or an
-// error occurs. Finally, we return our slice of arguments or an error if one occurred during resolution.
-func (d Dependency) resolveArguments(ctype reflect.Type) ([]any, error) {
+// error occurs. Finally, we return our slice of arguments or an error if one occurred during resolution. An Injectable
+// argument of a lazy parameter is not resolved, and the parameter receives a lazy resolver of it instead.
+func (d Dependency) resolveArguments(ctx context.Context, ctype reflect.Type) ([]any, error) {
 	args := make([]any, len(d.Args))
 
 	var res any
@@ -160,14 +267,26 @@ func (d Dependency) resolveArguments(ctype reflect.Type) ([]any, error) {
 	for i := 0; i < len(d.Args); i++ {
 		switch d.Args[i].(type) {
 		case Injectable:
-			arg := d.Args[i].(Injectable).SetContainer(d.container)
-			res, err = d.resolveArgument(i, arg, ctype)
+			arg := d.Args[i].(Injectable)
+			arg.container = d.container
+
+			if targ := d.paramType(ctype, i); types.InjectsLazy(d.container, arg.name, targ) {
+				res, err = arg.lazy(targ), nil
+				break
+			}
+
+			res, err = d.resolveArgument(ctx, i, arg, ctype)
 		case Dependency:
 			arg := d.Args[i].(Dependency).SetContainer(d.container)
-			res, err = d.resolveArgument(i, arg, ctype)
+			res, err = d.resolveArgument(ctx, i, arg, ctype)
+		case EnvVar:
+			res, err = d.Args[i].(EnvVar).resolve(d.paramType(ctype, i))
+			if err != nil {
+				err = fmt.Errorf("inject: error resolving argument %d for constructor %v: %w", i, ctype, err)
+			}
 		default:
 			arg := d.normalizeArgument(d.Args[i]).SetContainer(d.container)
-			res, err = d.resolveArgument(i, arg, ctype)
+			res, err = d.resolveArgument(ctx, i, arg, ctype)
 		}
 
 		if err != nil {
@@ -180,9 +299,19 @@ func (d Dependency) resolveArguments(ctype reflect.Type) ([]any, error) {
 	return args, nil
 }
 
-func (d Dependency) resolveArgument(index int, builder Builder, ctype reflect.Type) (any, error) {
-	errMsg := "inject: error resolving argument %d for constructor %v: %v"
-	res, err := builder.Build()
+// resolveArgument builds the argument on the given index, passing it the context if the builder can receive it.
+func (d Dependency) resolveArgument(ctx context.Context, index int, builder Builder, ctype reflect.Type) (any, error) {
+	var res any
+	var err error
+
+	errMsg := "inject: error resolving argument %d for constructor %v: %w"
+
+	if cb, ok := builder.(ContextBuilder); ok {
+		res, err = cb.BuildContext(ctx)
+	} else {
+		res, err = builder.Build()
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf(errMsg, index, ctype, err)
 	}
@@ -190,34 +319,27 @@ func (d Dependency) resolveArgument(index int, builder Builder, ctype reflect.Ty
 	return res, nil
 }
 
-// getValueAndError If the constructor returns no values, we return `nil` and `nil`. If the constructor returns one
-// value, we return that value and `nil`. If the constructor returns more than one value, we take the first as a result
-// and last as an error. We check if last argument is an error (if it's not nil). We return result and error (or just
-// nil if there was no error).
-func (d Dependency) getValueAndError(res []reflect.Value) (any, error) {
-	if len(res) == 0 {
-		return nil, nil
-	}
-
-	if len(res) == 1 {
-		return res[0].Interface(), nil
-	}
+// getValuesAndError If the last result type of the constructor is an error, we take it as the error of the build and
+// return it if it is not nil. Every other result is returned as a value, in the same order, so the first one is the
+// instance of the dependency and the rest are the extra outputs of a multi-output constructor.
+func (d Dependency) getValuesAndError(ctype reflect.Type, res []reflect.Value) ([]any, error) {
+	nvals := len(res)
 
-	argValue := res[0].Interface()
-	argErr := res[len(res)-1].Interface()
+	if nvals > 0 && ctype.Out(nvals-1) == errorType {
+		nvals--
 
-	var err error
+		if err, _ := res[nvals].Interface().(error); err != nil {
+			return nil, err
+		}
+	}
 
-	if argErr != nil {
-		var ok bool
+	vals := make([]any, nvals)
 
-		err, ok = argErr.(error)
-		if !ok {
-			return nil, fmt.Errorf("inject: last result argument of the constructor is not an error")
-		}
+	for i := 0; i < nvals; i++ {
+		vals[i] = res[i].Interface()
 	}
 
-	return argValue, err
+	return vals, nil
 }
 
 // normalizeArgument If the argument is nil, we return a new dependency that returns nil. If the argument is already a
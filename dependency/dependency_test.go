@@ -1,6 +1,7 @@
 package dependency
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -131,8 +132,10 @@ func TestDependency_Build(t *testing.T) {
 	})
 
 	t.Run("nested deps error", func(t *testing.T) {
+		errSome := errors.New("some")
+
 		dep := New(func(string) bool { return true },
-			New(func() (string, error) { return "", errors.New("some") }),
+			New(func() (string, error) { return "", errSome }),
 		)
 
 		res, err := dep.Build()
@@ -141,7 +144,8 @@ func TestDependency_Build(t *testing.T) {
 
 		assert.Error(t, err)
 		assert.Nil(t, res)
-		assert.Equal(t, expErr, err)
+		assert.EqualError(t, err, expErr.Error())
+		assert.ErrorIs(t, err, errSome)
 	})
 
 	t.Run("no arguments single return value", func(t *testing.T) {
@@ -176,11 +180,8 @@ func TestDependency_Build(t *testing.T) {
 
 		res, err := dep.Build()
 
-		expErr := errors.New("inject: error constructing `func() (*dependency.value, int)`: inject: last result argument of the constructor is not an error")
-
-		assert.Error(t, err)
-		assert.Nil(t, res)
-		assert.Equal(t, expErr, err)
+		assert.NoError(t, err)
+		assert.IsType(t, &value{}, res)
 	})
 
 	t.Run("no arguments and more than two return values", func(t *testing.T) {
@@ -209,7 +210,8 @@ func TestDependency_Build(t *testing.T) {
 
 		assert.Error(t, err)
 		assert.Nil(t, res)
-		assert.Equal(t, expErr, err)
+		assert.EqualError(t, err, expErr.Error())
+		assert.ErrorIs(t, err, constErr)
 	})
 
 	t.Run("with arguments and two return values", func(t *testing.T) {
@@ -267,7 +269,7 @@ func TestDependency_Build(t *testing.T) {
 
 		assert.Error(t, err)
 		assert.Nil(t, res)
-		assert.Equal(t, expErr, err)
+		assert.EqualError(t, err, expErr.Error())
 	})
 
 	t.Run("with arguments and error by wrong argument order or type", func(t *testing.T) {
@@ -447,4 +449,132 @@ func TestDependency_Build(t *testing.T) {
 		assert.Equal(t, (any)(1), res)
 		assert.Equal(t, injectDepValue, injectedValue)
 	})
+
+	t.Run("with lazy injectable dependency", func(t *testing.T) {
+		injectDepName := types.Symbol("inject")
+
+		ic := mocks.NewContainer(t)
+
+		var lazyName func() (string, error)
+
+		dep := New(func(name types.Lazy[string]) int {
+			lazyName = name
+			return 1
+		}, Inject(injectDepName))
+
+		res, err := dep.SetContainer(ic).Build()
+
+		assert.NoError(t, err)
+		assert.Equal(t, (any)(1), res)
+
+		ic.On("Get", injectDepName).Return("some", nil).Twice()
+
+		for i := 0; i < 2; i++ {
+			name, err := lazyName()
+
+			assert.NoError(t, err)
+			assert.Equal(t, "some", name)
+		}
+	})
+
+	t.Run("with lazy injectable dependency of wrong type", func(t *testing.T) {
+		injectDepName := types.Symbol("inject")
+
+		ic := mocks.NewContainer(t)
+		ic.On("Get", injectDepName).Return(10, nil)
+
+		dep := New(func(name func() (string, error)) (string, error) {
+			return name()
+		}, Inject(injectDepName))
+
+		_, err := dep.SetContainer(ic).Build()
+
+		assert.EqualError(t, err, "inject: error constructing `func(func() (string, error)) (string, error)`: inject: using int as type string on lazy dependency")
+	})
+}
+
+func TestDependency_As(t *testing.T) {
+	dep := New(newUser, "John", 21)
+	bound := dep.As(new(namer)).As(new(ager))
+
+	assert.Empty(t, dep.Interfaces)
+	assert.Len(t, bound.Interfaces, 2)
+	assert.Equal(t, dep.String(), bound.String())
+}
+
+func TestNewScoped(t *testing.T) {
+	dep := NewScoped(func() {})
+
+	assert.True(t, dep.IsScoped())
+	assert.False(t, dep.IsSingleton())
+
+	dep.Singleton = true
+
+	assert.False(t, dep.IsScoped())
+}
+
+func TestDependency_BuildContext(t *testing.T) {
+	type ctxKey struct{}
+
+	t.Run("pass context to factory", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+		dep := New(func(ctx context.Context, name string) string {
+			return ctx.Value(ctxKey{}).(string) + " " + name
+		}, "John")
+
+		res, err := dep.BuildContext(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "value John", res)
+	})
+
+	t.Run("pass context to nested dependencies", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+		dep := New(func(name string) string { return name }, New(func(ctx context.Context) string {
+			return ctx.Value(ctxKey{}).(string)
+		}))
+
+		res, err := dep.BuildContext(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "value", res)
+	})
+
+	t.Run("explicit context argument", func(t *testing.T) {
+		dep := New(func(ctx context.Context) bool { return ctx == nil }, nil)
+
+		res, err := dep.BuildContext(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, true, res)
+	})
+
+	t.Run("build with background context", func(t *testing.T) {
+		dep := New(func(ctx context.Context) error { return ctx.Err() })
+
+		res, err := dep.Build()
+
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("stop on canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		called := false
+
+		dep := New(func(ctx context.Context, name string) string {
+			called = true
+			return name
+		}, New(func() string {
+			cancel()
+			return "John"
+		}))
+
+		_, err := dep.BuildContext(ctx)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.False(t, called)
+	})
 }
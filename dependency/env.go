@@ -0,0 +1,238 @@
+package dependency
+
+import (
+	"encoding"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EnvVar is an argument that reads its value from an environment variable when the dependency is built, instead of
+// when it is registered. The value is converted to the type of the constructor parameter it is passed to.
+type EnvVar struct {
+	name       string
+	def        any
+	hasDefault bool
+}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Env return an argument that is read from the given environment variable when the dependency is built. Building fails
+// if the variable is not set.
+func Env(name string) EnvVar {
+	return EnvVar{name: name}
+}
+
+// EnvOr is the same as Env, but if the variable is not set, the default value is used instead. A string default value
+// is converted in the same way as the variable value, and a number or boolean default value of a string parameter is
+// formatted as text.
+func EnvOr(name string, def any) EnvVar {
+	return EnvVar{name: name, def: def, hasDefault: true}
+}
+
+// Name returns the name of the environment variable.
+func (e EnvVar) Name() string {
+	return e.name
+}
+
+// resolve reads the environment variable and converts its value to the given parameter type.
+func (e EnvVar) resolve(targ reflect.Type) (any, error) {
+	value, ok := os.LookupEnv(e.name)
+
+	if !ok && !e.hasDefault {
+		return nil, fmt.Errorf("inject: environment variable `%s` is not set", e.name)
+	}
+
+	if !ok {
+		return e.defaultValue(targ)
+	}
+
+	res, err := parseEnvValue(value, targ)
+	if err != nil {
+		return nil, fmt.Errorf("inject: can't convert environment variable `%s` to %v: %w", e.name, targ, err)
+	}
+
+	return res, nil
+}
+
+// defaultValue returns the default value as the given parameter type.
+func (e EnvVar) defaultValue(targ reflect.Type) (any, error) {
+	if e.def == nil {
+		return reflect.Zero(targ).Interface(), nil
+	}
+
+	dtype := reflect.TypeOf(e.def)
+
+	switch {
+	case dtype.AssignableTo(targ):
+		return e.def, nil
+	case dtype.Kind() == reflect.String:
+		return e.parseDefault(reflect.ValueOf(e.def).String(), targ)
+	case isNumber(dtype) && isNumber(targ):
+		return reflect.ValueOf(e.def).Convert(targ).Interface(), nil
+	case targ.Kind() == reflect.String && (isNumber(dtype) || dtype.Kind() == reflect.Bool):
+		// Formatted as text, since converting a number to a string would take it as a rune.
+		return e.parseDefault(fmt.Sprint(e.def), targ)
+	default:
+		return nil, fmt.Errorf("inject: using %v as default value of environment variable `%s` of type %v", dtype, e.name, targ)
+	}
+}
+
+// parseDefault converts the default value, given as text, to the given parameter type.
+func (e EnvVar) parseDefault(value string, targ reflect.Type) (any, error) {
+	res, err := parseEnvValue(value, targ)
+	if err != nil {
+		return nil, fmt.Errorf("inject: can't convert default value of environment variable `%s` to %v: %w", e.name, targ, err)
+	}
+
+	return res, nil
+}
+
+// isNumber returns true if the type is an integer or floating point number.
+func isNumber(rt reflect.Type) bool {
+	switch rt.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// validate checks that the value of the environment variable can be converted to the given parameter type, and that
+// the default value, if any, can be used as it.
+func (e EnvVar) validate(targ reflect.Type) error {
+	if !isEnvType(targ) {
+		return fmt.Errorf("inject: environment variable `%s` can't be converted to %v", e.name, targ)
+	}
+
+	if !e.hasDefault {
+		return nil
+	}
+
+	_, err := e.defaultValue(targ)
+
+	return err
+}
+
+// isEnvType returns true if a string value can be converted to the given type by parseEnvValue.
+func isEnvType(rt reflect.Type) bool {
+	if rt == durationType || reflect.PointerTo(rt).Implements(textUnmarshalerType) {
+		return true
+	}
+
+	switch rt.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return isEnvType(rt.Elem())
+	case reflect.Interface:
+		return reflect.TypeOf("").Implements(rt)
+	default:
+		return false
+	}
+}
+
+// parseEnvValue converts the string value to the given type. Types that implement encoding.TextUnmarshaler are
+// unmarshalled, durations are parsed with time.ParseDuration, and slices are read as comma separated values.
+func parseEnvValue(value string, rt reflect.Type) (any, error) {
+	res := reflect.New(rt).Elem()
+
+	if err := setEnvValue(value, res); err != nil {
+		return nil, err
+	}
+
+	return res.Interface(), nil
+}
+
+func setEnvValue(value string, res reflect.Value) error {
+	rt := res.Type()
+
+	if u, ok := res.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(value))
+	}
+
+	if rt == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+
+		res.SetInt(int64(d))
+
+		return nil
+	}
+
+	switch rt.Kind() {
+	case reflect.String:
+		res.SetString(value)
+	case reflect.Interface:
+		res.Set(reflect.ValueOf(value))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+
+		res.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, rt.Bits())
+		if err != nil {
+			return err
+		}
+
+		res.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, rt.Bits())
+		if err != nil {
+			return err
+		}
+
+		res.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, rt.Bits())
+		if err != nil {
+			return err
+		}
+
+		res.SetFloat(f)
+	case reflect.Slice:
+		return setEnvSlice(value, res)
+	default:
+		return fmt.Errorf("unsupported type %v", rt)
+	}
+
+	return nil
+}
+
+// setEnvSlice sets every comma separated item of the value as an element of the slice. An empty value is an empty
+// slice.
+func setEnvSlice(value string, res reflect.Value) error {
+	if strings.TrimSpace(value) == "" {
+		res.Set(reflect.MakeSlice(res.Type(), 0, 0))
+		return nil
+	}
+
+	items := strings.Split(value, ",")
+	slice := reflect.MakeSlice(res.Type(), len(items), len(items))
+
+	for i, item := range items {
+		if err := setEnvValue(strings.TrimSpace(item), slice.Index(i)); err != nil {
+			return fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+
+	res.Set(slice)
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package dependency
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type envConfig struct {
+	URL     string
+	Port    int
+	Debug   bool
+	Timeout time.Duration
+	Hosts   []string
+}
+
+func newEnvConfig(url string, port int, debug bool, timeout time.Duration, hosts []string) envConfig {
+	return envConfig{URL: url, Port: port, Debug: debug, Timeout: timeout, Hosts: hosts}
+}
+
+func TestEnv(t *testing.T) {
+	t.Run("convert variables to the parameter types", func(t *testing.T) {
+		t.Setenv("INJECT_TEST_URL", "postgres://main")
+		t.Setenv("INJECT_TEST_PORT", "5432")
+		t.Setenv("INJECT_TEST_DEBUG", "true")
+		t.Setenv("INJECT_TEST_TIMEOUT", "1m30s")
+		t.Setenv("INJECT_TEST_HOSTS", "a, b,c")
+
+		dep := New(newEnvConfig,
+			Env("INJECT_TEST_URL"),
+			Env("INJECT_TEST_PORT"),
+			Env("INJECT_TEST_DEBUG"),
+			Env("INJECT_TEST_TIMEOUT"),
+			Env("INJECT_TEST_HOSTS"),
+		)
+
+		val, err := dep.Build()
+
+		assert.NoError(t, err)
+		assert.Equal(t, envConfig{
+			URL:     "postgres://main",
+			Port:    5432,
+			Debug:   true,
+			Timeout: 90 * time.Second,
+			Hosts:   []string{"a", "b", "c"},
+		}, val)
+	})
+
+	t.Run("read variables when built", func(t *testing.T) {
+		dep := New(func(port int) int { return port }, Env("INJECT_TEST_PORT"))
+
+		t.Setenv("INJECT_TEST_PORT", "80")
+
+		val, err := dep.Build()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 80, val)
+	})
+
+	t.Run("use default values when not set", func(t *testing.T) {
+		dep := New(newEnvConfig,
+			EnvOr("INJECT_TEST_URL", "postgres://local"),
+			EnvOr("INJECT_TEST_PORT", 8080),
+			EnvOr("INJECT_TEST_DEBUG", nil),
+			EnvOr("INJECT_TEST_TIMEOUT", "5s"),
+			EnvOr("INJECT_TEST_HOSTS", []string{"localhost"}),
+		)
+
+		val, err := dep.Build()
+
+		assert.NoError(t, err)
+		assert.Equal(t, envConfig{
+			URL:     "postgres://local",
+			Port:    8080,
+			Timeout: 5 * time.Second,
+			Hosts:   []string{"localhost"},
+		}, val)
+	})
+
+	t.Run("format non string default values as text", func(t *testing.T) {
+		dep := New(func(port string, debug string) string { return ":" + port + " " + debug },
+			EnvOr("INJECT_TEST_PORT", 8080),
+			EnvOr("INJECT_TEST_DEBUG", true),
+		)
+
+		assert.NoError(t, dep.Validate(registry{}))
+
+		val, err := dep.Build()
+
+		assert.NoError(t, err)
+		assert.Equal(t, ":8080 true", val)
+	})
+
+	t.Run("unmarshal text values", func(t *testing.T) {
+		t.Setenv("INJECT_TEST_IP", "127.0.0.1")
+
+		val, err := New(func(ip net.IP) string { return ip.String() }, Env("INJECT_TEST_IP")).Build()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "127.0.0.1", val)
+	})
+
+	t.Run("variable not set", func(t *testing.T) {
+		_, err := New(func(url string) string { return url }, Env("INJECT_TEST_URL")).Build()
+
+		assert.EqualError(t, err, "inject: error resolving argument 0 for constructor func(string) string: inject: environment variable `INJECT_TEST_URL` is not set")
+	})
+
+	t.Run("invalid variable value", func(t *testing.T) {
+		t.Setenv("INJECT_TEST_PORT", "http")
+
+		_, err := New(func(port int) int { return port }, Env("INJECT_TEST_PORT")).Build()
+
+		assert.ErrorContains(t, err, "inject: can't convert environment variable `INJECT_TEST_PORT` to int")
+	})
+
+	t.Run("validate parameter and default types", func(t *testing.T) {
+		dep := New(func(port int, conn db) bool { return true },
+			EnvOr("INJECT_TEST_PORT", true),
+			Env("INJECT_TEST_DB"),
+		)
+
+		err := dep.Validate(registry{})
+
+		assert.ErrorContains(t, err, "inject: using bool as default value of environment variable `INJECT_TEST_PORT` of type int")
+		assert.ErrorContains(t, err, "inject: environment variable `INJECT_TEST_DB` can't be converted to dependency.db")
+	})
+}
@@ -1,7 +1,9 @@
 package dependency
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 
 	"github.com/Drafteame/inject/types"
 )
@@ -10,10 +12,11 @@ import (
 // three, stored on the container. This Dependency will be accessed by his associated name on the container.
 type Injectable struct {
 	name      types.Symbol
+	rtype     reflect.Type
 	container Container
 }
 
-var _ Builder = &Injectable{}
+var _ ContextBuilder = &Injectable{}
 
 // Inject return an instance of Injectable dependency.
 func Inject(name types.Symbol) Injectable {
@@ -22,14 +25,63 @@ func Inject(name types.Symbol) Injectable {
 	}
 }
 
+// InjectKey return an instance of Injectable dependency that references the dependency of the key. The key type is
+// checked against the referenced dependency and the constructor parameter when the dependency is validated.
+func InjectKey[T any](key types.Key[T]) Injectable {
+	return Injectable{
+		name:  key.Name(),
+		rtype: key.Type(),
+	}
+}
+
+// Name returns the name of the dependency referenced by the Injectable.
+func (s Injectable) Name() types.Symbol {
+	return s.name
+}
+
+// WithName returns a copy of the Injectable that references the dependency of the given name, keeping the key type, if
+// any.
+func (s Injectable) WithName(name types.Symbol) Injectable {
+	s.name = name
+	return s
+}
+
 func (s Injectable) Build() (any, error) {
+	return s.BuildContext(context.Background())
+}
+
+// BuildContext resolves the referenced dependency from the container, passing it the context if the container can
+// receive it.
+func (s Injectable) BuildContext(ctx context.Context) (any, error) {
 	if s.container == nil {
 		return nil, fmt.Errorf("inject: [internal-error] no container provided")
 	}
 
+	if cc, ok := s.container.(ContextContainer); ok {
+		return cc.GetContext(ctx, s.name)
+	}
+
 	return s.container.Get(s.name)
 }
 
+// lazy returns a function of the given lazy type that resolves the referenced dependency every time it is called, on a
+// new top level resolution of the container.
+func (s Injectable) lazy(ltype reflect.Type) any {
+	var cont Container = s.container
+
+	if d, ok := s.container.(types.Detacher); ok {
+		cont = d.Detach()
+	}
+
+	return types.NewLazy(ltype, func() (any, error) {
+		if cont == nil {
+			return nil, fmt.Errorf("inject: [internal-error] no container provided")
+		}
+
+		return cont.Get(s.name)
+	}).Interface()
+}
+
 func (s Injectable) IsSingleton() bool {
 	return false
 }
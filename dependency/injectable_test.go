@@ -18,6 +18,22 @@ func TestInject(t *testing.T) {
 	assert.Equal(t, name, i.name)
 }
 
+func TestInjectKey(t *testing.T) {
+	key := types.NewKey[*user]("test")
+	i := InjectKey(key)
+
+	assert.Equal(t, key.Name(), i.Name())
+	assert.Equal(t, key.Type(), i.rtype)
+}
+
+func TestInjectable_WithName(t *testing.T) {
+	key := types.NewKey[*user]("test")
+	i := InjectKey(key).WithName("users.test")
+
+	assert.Equal(t, types.Symbol("users.test"), i.Name())
+	assert.Equal(t, key.Type(), i.rtype)
+}
+
 func TestInjectable_IsSingleton(t *testing.T) {
 	name := types.Symbol("test")
 	i := Inject(name)
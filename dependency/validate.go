@@ -0,0 +1,218 @@
+package dependency
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/Drafteame/inject/types"
+	"github.com/Drafteame/inject/utils"
+)
+
+// Registry gives access to the provided dependencies without building them, so a dependency three can be checked
+// before any factory is called.
+type Registry interface {
+	Lookup(name types.Symbol) (Dependency, bool)
+}
+
+// Validate checks the dependency three without calling any factory. It makes the same checks that Build does over the
+// constructor and its arguments: the constructor should be a function, the number of arguments should match its
+// signature, and each argument should be assignable to its parameter. Nested dependencies are checked recursively, and
+// each Injectable argument should reference a dependency provided on the registry. It returns every problem found as
+// a types.Errors value, or nil if there is none.
+func (d Dependency) Validate(reg Registry) error {
+	return types.Errors(d.validate(reg)).ErrOrNil()
+}
+
+// CheckKeys checks that the key type of every argument built with InjectKey, including the ones of nested dependencies,
+// can be used as the constructor parameter it is passed to. Unlike Validate, it doesn't need the referenced
+// dependencies, so it can be made when the dependency is provided. Other problems of the constructor are left to
+// Validate and Build.
+func (d Dependency) CheckKeys() error {
+	ctype, err := d.validateAndGetReflectType()
+	if err != nil {
+		return nil
+	}
+
+	errs := make([]error, 0)
+
+	for i, arg := range d.Args {
+		switch a := arg.(type) {
+		case Injectable:
+			if a.rtype == nil {
+				continue
+			}
+
+			targ := d.paramType(ctype, i)
+
+			if types.IsLazy(targ) && !a.rtype.AssignableTo(targ) {
+				targ = targ.Out(0)
+			}
+
+			if err := checkArgumentType(ctype, targ, a.rtype); err != nil {
+				errs = append(errs, err)
+			}
+		case Dependency:
+			if err := a.CheckKeys(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return types.Errors(errs).ErrOrNil()
+}
+
+// References returns the names of every dependency that is injected on the dependency three, including the ones
+// injected on nested dependencies, in the same order they appear on the arguments.
+func (d Dependency) References() []types.Symbol {
+	refs := make([]types.Symbol, 0)
+
+	for _, arg := range d.Args {
+		switch a := arg.(type) {
+		case Injectable:
+			refs = append(refs, a.name)
+		case Dependency:
+			refs = append(refs, a.References()...)
+		}
+	}
+
+	return refs
+}
+
+// EagerReferences is the same as References, but it leaves out the dependencies injected as lazy parameters, since
+// they are not built along with the dependency and so they can't be part of a dependency cycle.
+func (d Dependency) EagerReferences() []types.Symbol {
+	refs := make([]types.Symbol, 0)
+	ctype, err := d.validateAndGetReflectType()
+
+	for i, arg := range d.Args {
+		switch a := arg.(type) {
+		case Injectable:
+			if err == nil && types.IsLazy(d.paramType(ctype, i)) {
+				continue
+			}
+
+			refs = append(refs, a.name)
+		case Dependency:
+			refs = append(refs, a.EagerReferences()...)
+		}
+	}
+
+	return refs
+}
+
+func (d Dependency) validate(reg Registry) []error {
+	ctype, err := d.validateAndGetReflectType()
+	if err != nil {
+		return []error{err}
+	}
+
+	errs := make([]error, 0)
+
+	for i, arg := range d.Args {
+		if env, ok := arg.(EnvVar); ok {
+			if err := env.validate(d.paramType(ctype, i)); err != nil {
+				errs = append(errs, fmt.Errorf("inject: error resolving argument %d for constructor %v: %w", i, ctype, err))
+			}
+
+			continue
+		}
+
+		atype, argErrs := d.validateArgument(arg, reg)
+
+		for _, err := range argErrs {
+			errs = append(errs, fmt.Errorf("inject: error resolving argument %d for constructor %v: %w", i, ctype, err))
+		}
+
+		if len(argErrs) > 0 {
+			continue
+		}
+
+		targ := d.paramType(ctype, i)
+
+		// A dependency whose value is already of the lazy type is injected as it is.
+		if _, ok := arg.(Injectable); ok && types.IsLazy(targ) && (atype == nil || !atype.AssignableTo(targ)) {
+			targ = targ.Out(0)
+		}
+
+		if err := checkArgumentType(ctype, targ, atype); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// validateArgument checks an argument of the dependency and returns the type of the value it will produce. A nil type
+// means that the argument will produce an untyped nil value.
+func (d Dependency) validateArgument(arg any, reg Registry) (reflect.Type, []error) {
+	switch a := arg.(type) {
+	case Injectable:
+		dep, ok := reg.Lookup(a.name)
+		if !ok {
+			return nil, []error{&types.NotFoundError{Name: a.name}}
+		}
+
+		rt := utils.GetFirstReturnType(dep.Factory)
+
+		if a.rtype == nil {
+			return rt, nil
+		}
+
+		if err := checkKeyType(a.name, a.rtype, rt); err != nil {
+			return nil, []error{err}
+		}
+
+		return a.rtype, nil
+	case Dependency:
+		return a.validateNested(reg)
+	default:
+		if arg == nil {
+			return nil, nil
+		}
+
+		if reflect.TypeOf(arg).Kind() == reflect.Func {
+			return New(arg).validateNested(reg)
+		}
+
+		return reflect.TypeOf(arg), nil
+	}
+}
+
+func (d Dependency) validateNested(reg Registry) (reflect.Type, []error) {
+	if errs := d.validate(reg); len(errs) > 0 {
+		return nil, errs
+	}
+
+	return utils.GetFirstReturnType(d.Factory), nil
+}
+
+// checkKeyType checks that the instances of a dependency, of type `rt`, can be used as the key type `ktype`.
+func checkKeyType(name types.Symbol, ktype, rt reflect.Type) error {
+	if rt != nil && rt.AssignableTo(ktype) {
+		return nil
+	}
+
+	return fmt.Errorf("inject: dependency `%s` of type %v can't be used as key of type %v", name, rt, ktype)
+}
+
+// checkArgumentType checks that a value of type `atype` can be used as the parameter of type `targ` of the
+// constructor. When `atype` is an interface, the check passes if the value it holds at runtime could be assignable.
+func checkArgumentType(ctype, targ, atype reflect.Type) error {
+	if atype == nil {
+		if targ.Kind() == reflect.Interface || targ.Kind() == reflect.Ptr {
+			return nil
+		}
+
+		return fmt.Errorf("inject: using untyped nil as type %s on constructor `%v`", targ.String(), ctype)
+	}
+
+	if atype.AssignableTo(targ) {
+		return nil
+	}
+
+	if atype.Kind() == reflect.Interface && (targ.Kind() == reflect.Interface || targ.Implements(atype)) {
+		return nil
+	}
+
+	return fmt.Errorf("inject: using %s as type %s on constructor `%v`", atype.String(), targ.String(), ctype)
+}
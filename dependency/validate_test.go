@@ -0,0 +1,104 @@
+package dependency
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Drafteame/inject/types"
+)
+
+type registry map[types.Symbol]Dependency
+
+func (r registry) Lookup(name types.Symbol) (Dependency, bool) {
+	dep, ok := r[name]
+	return dep, ok
+}
+
+func TestDependency_Validate(t *testing.T) {
+	t.Run("valid nested arguments", func(t *testing.T) {
+		reg := registry{"conn": New(newDatabase, "main")}
+
+		dep := New(func(u userer, conn db) bool { return true },
+			New(newUser, "John", func() int { return 21 }),
+			Inject("conn"),
+		)
+
+		assert.NoError(t, dep.Validate(reg))
+	})
+
+	t.Run("nil arguments", func(t *testing.T) {
+		dep := New(func(u userer, name string) bool { return true }, nil, nil)
+
+		err := dep.Validate(registry{})
+
+		expErr := types.Errors{errors.New("inject: using untyped nil as type string on constructor `func(dependency.userer, string) bool`")}
+
+		assert.Equal(t, expErr, err)
+	})
+
+	t.Run("interface results are checked as assignable", func(t *testing.T) {
+		reg := registry{"user": New(func() namer { return &user{} })}
+
+		dep := New(newUserConn, Inject("user"))
+
+		assert.NoError(t, dep.Validate(reg))
+	})
+
+	t.Run("lazy arguments are checked by their value type", func(t *testing.T) {
+		reg := registry{"conn": New(newDatabase, "main")}
+
+		assert.NoError(t, New(func(conn types.Lazy[db]) bool { return true }, Inject("conn")).Validate(reg))
+
+		err := New(func(conn func() (string, error)) bool { return true }, Inject("conn")).Validate(reg)
+
+		expErr := types.Errors{errors.New("inject: using *dependency.database as type string on constructor `func(func() (string, error)) bool`")}
+
+		assert.Equal(t, expErr, err)
+	})
+
+	t.Run("key arguments are checked by their key type", func(t *testing.T) {
+		reg := registry{"conn": New(newDatabase, "main")}
+
+		assert.NoError(t, New(func(conn db) bool { return true }, InjectKey(types.NewKey[db]("conn"))).Validate(reg))
+
+		err := New(func(conn db) bool { return true }, InjectKey(types.NewKey[*user]("conn"))).Validate(reg)
+
+		assert.EqualError(t, err, "inject: error resolving argument 0 for constructor func(dependency.db) bool: inject: dependency `conn` of type *dependency.database can't be used as key of type *dependency.user")
+	})
+
+	t.Run("invalid constructor", func(t *testing.T) {
+		err := New(10).Validate(registry{})
+
+		expErr := types.Errors{errors.New("inject: must provide constructor function, got `int`")}
+
+		assert.Equal(t, expErr, err)
+	})
+}
+
+func TestDependency_CheckKeys(t *testing.T) {
+	assert.NoError(t, New(func(conn db, lazy types.Lazy[db]) bool { return true },
+		InjectKey(types.NewKey[*database]("conn")),
+		InjectKey(types.NewKey[db]("conn")),
+	).CheckKeys())
+
+	err := New(func(conn db, name string) bool { return true },
+		InjectKey(types.NewKey[db]("conn")),
+		New(func(u *user) string { return u.name }, InjectKey(types.NewKey[db]("conn"))),
+	).CheckKeys()
+
+	assert.EqualError(t, err, "inject: using dependency.db as type *dependency.user on constructor `func(*dependency.user) string`")
+}
+
+func TestDependency_References(t *testing.T) {
+	dep := New(func(string, int, bool) {}, Inject("a"), New(func(int) int { return 0 }, Inject("b")), true)
+
+	assert.Equal(t, []types.Symbol{"a", "b"}, dep.References())
+}
+
+func TestDependency_EagerReferences(t *testing.T) {
+	dep := New(func(types.Lazy[string], int, bool) {}, Inject("a"), New(func(int) int { return 0 }, Inject("b")), true)
+
+	assert.Equal(t, []types.Symbol{"b"}, dep.EagerReferences())
+}
@@ -0,0 +1,44 @@
+package inject
+
+import "github.com/Drafteame/inject/types"
+
+var (
+	// ErrNotFound is matched by errors.Is when a dependency was not provided, by name or by type.
+	ErrNotFound = types.ErrNotFound
+
+	// ErrDuplicate is matched by errors.Is when a dependency name was already provided.
+	ErrDuplicate = types.ErrDuplicate
+
+	// ErrAmbiguous is matched by errors.Is when a type is resolved and more than one dependency was provided for it.
+	ErrAmbiguous = types.ErrAmbiguous
+
+	// ErrCycle is matched by errors.Is when a dependency needs itself to be built.
+	ErrCycle = types.ErrCycle
+
+	// ErrClosed is returned by every operation of a container that was already closed.
+	ErrClosed = types.ErrClosed
+)
+
+type (
+	// NotFoundError is returned when a dependency was not provided.
+	NotFoundError = types.NotFoundError
+
+	// DuplicateError is returned when a dependency is provided with a name that is already taken.
+	DuplicateError = types.DuplicateError
+
+	// AmbiguousError is returned when a type is resolved and there is more than one dependency provided for it.
+	AmbiguousError = types.AmbiguousError
+
+	// CycleError is returned when a dependency needs itself to be built, and holds the whole resolution path.
+	CycleError = types.CycleError
+
+	// BuildError is returned when a provided dependency can't be built, and wraps the underlying error.
+	BuildError = types.BuildError
+
+	// ModuleError is returned when a module can't be installed, and names the module that caused the problem.
+	ModuleError = types.ModuleError
+
+	// DefinitionError is returned when a definition file can't be loaded, and points at the line and key that caused
+	// the problem.
+	DefinitionError = types.DefinitionError
+)
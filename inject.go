@@ -1,15 +1,20 @@
 package inject
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"sync"
 
 	"github.com/Drafteame/inject/container"
 	"github.com/Drafteame/inject/dependency"
 	"github.com/Drafteame/inject/types"
 )
 
-var injector Container
+var (
+	injector     Container
+	injectorOnce sync.Once
+)
 
 type symbolName interface {
 	string | types.Symbol
@@ -18,18 +23,40 @@ type symbolName interface {
 // Container represents a dependency container that should register factory methods and its dependency threes to be
 // injected when
 type Container interface {
-	Provide(name types.Symbol, dep dependency.Dependency) error
+	Provide(name types.Symbol, dep dependency.Dependency, opts ...container.ProvideOption) error
+	ProvideType(dep dependency.Dependency, opts ...container.ProvideOption) error
+	Install(m container.Module) error
 	Invoke(construct any) error
+	InvokeContext(ctx context.Context, construct any) error
+	Call(construct any) (any, error)
+	CallContext(ctx context.Context, construct any) (any, error)
 	Get(name types.Symbol) (any, error)
+	GetContext(ctx context.Context, name types.Symbol) (any, error)
+	Resolve(rtype reflect.Type) (any, error)
+	GetGroup(name types.Symbol) ([]any, error)
+	Populate(target any) error
+	PopulateStrict(target any) error
+	Scope(name string) *container.Container
+	Decorate(name types.Symbol, fn any, args ...any) error
+	Replace(name types.Symbol, dep dependency.Dependency) error
+	Snapshot() container.Snapshot
+	Restore(s container.Snapshot)
+	Validate() error
+	Graph() container.Graph
+	Close(ctx context.Context) error
 	Flush()
+	FlushAndClose(ctx context.Context) error
 }
 
 // get return a global instance for the dependency injection container. If the container is nil, then it will initialize
-// a new instance before returning the container.
+// a new instance before returning the container. The initialization happens only once, so it is safe to call it from
+// many goroutines.
 func get() Container {
-	if injector == nil {
-		injector = container.New()
-	}
+	injectorOnce.Do(func() {
+		if injector == nil {
+			injector = container.New()
+		}
+	})
 
 	return injector
 }
@@ -40,12 +67,21 @@ func New() Container {
 	return container.New()
 }
 
+// Scope Return a child of the global container that resolves the dependencies it doesn't have from the global one.
+// Dependencies provided on the scope shadow the global ones, and dependencies created with `dependency.NewScoped` are
+// built once per scope.
+func Scope(name string) Container {
+	return get().Scope(name)
+}
+
 // Provide Is a wrapper over the Provide function attached to the global container. It adds a new injection dependency
 // to the container, getting the first result type of the constructor to associate the constructor on the injection
 // dependency threes.
 //
 // This injection will be resolved and built on execution time when the `inject.Invoke(...)` or `inject.Get(name)`
 // methods are called.
+//
+// Provide options, like `inject.WithSingleton()`, can be passed along with the arguments, and are applied in order.
 func Provide[T symbolName](name T, factory any, args ...any) error {
 	return provide(types.Symbol(name), false, factory, args...)
 }
@@ -59,6 +95,89 @@ func Singleton[T symbolName](name T, factory any, args ...any) error {
 	return provide(types.Symbol(name), true, factory, args...)
 }
 
+// ProvideType Is a wrapper over the ProvideType function attached to the global container. It adds a new injection
+// dependency without a name, that can only be resolved by the first return type of its factory using `inject.Resolve`
+// or untagged fields of `types.In` structs.
+func ProvideType(factory any, args ...any) error {
+	dep, err := newDependency(false, factory, args...)
+	if err != nil {
+		return err
+	}
+
+	return get().ProvideType(dep)
+}
+
+// SingletonType Is the same as ProvideType, but the dependency will be registered as a singleton.
+func SingletonType(factory any, args ...any) error {
+	dep, err := newDependency(true, factory, args...)
+	if err != nil {
+		return err
+	}
+
+	return get().ProvideType(dep)
+}
+
+// Module creates a set of dependencies that is installed as a unit with `inject.Install`. Each dependency is provided
+// with the module name as a prefix, e.g. `payments.client`, and injected names that reference other dependency of the
+// same module are prefixed too.
+func Module(name string, registrations ...container.Registration) container.Module {
+	return container.NewModule(name, registrations...)
+}
+
+// Register creates a registration of a public dependency of a module. It can receive a factory function with its
+// arguments, or an already created dependency.Dependency.
+func Register[T symbolName](name T, factory any, args ...any) container.Registration {
+	return container.Register(types.Symbol(name), moduleDependency(factory, args...))
+}
+
+// Private creates a registration of a dependency that can only be injected on other dependencies of the same module.
+// It can receive a factory function with its arguments, or an already created dependency.Dependency.
+func Private[T symbolName](name T, factory any, args ...any) container.Registration {
+	return container.Private(types.Symbol(name), moduleDependency(factory, args...))
+}
+
+// Install Is a wrapper over the Install function attached to the global container. It installs each module as a unit,
+// stopping on the first module that can't be installed.
+func Install(modules ...container.Module) error {
+	for _, m := range modules {
+		if err := get().Install(m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Decorate Is a wrapper over the Decorate function attached to the global container. It registers a function that
+// receives the built instance of the dependency, along with the provided arguments, and returns a replacement for it.
+// Decorators are applied in registration order, and singletons are decorated only once before being cached.
+func Decorate[T symbolName](name T, fn any, args ...any) error {
+	return get().Decorate(types.Symbol(name), fn, args...)
+}
+
+// Replace Is a wrapper over the Replace function attached to the global container. It swaps the registration of an
+// already provided dependency, dropping any cached instance that depends on it. It can receive a factory function with
+// its arguments, or an already created dependency.Dependency that is registered as is.
+func Replace[T symbolName](name T, factory any, args ...any) error {
+	dep, err := newDependency(false, factory, args...)
+	if err != nil {
+		return err
+	}
+
+	return get().Replace(types.Symbol(name), dep)
+}
+
+// Snapshot saves the current registrations and cached instances of the global container, so they can be restored after
+// overriding some dependencies on a test.
+func Snapshot() container.Snapshot {
+	return get().Snapshot()
+}
+
+// Restore sets back the registrations and cached instances of the global container saved on the snapshot.
+func Restore(s container.Snapshot) {
+	get().Restore(s)
+}
+
 // Invoke Is the entry point to execute dependency injection resolution. It calls an invoker function that can
 // receive or not a struct that embeds inject.In struct as input, and return an error or not (any other return field or
 // type will be ignored on resolution). When invoker is called it will resolve the dependency threes of each field from
@@ -67,11 +186,43 @@ func Invoke(construct any) error {
 	return get().Invoke(construct)
 }
 
+// InvokeContext is the same as Invoke, but the dependency threes are resolved with the given context, that is passed to
+// every factory that receives a context.Context as first parameter, and to every invoker parameter or `types.In` field
+// of type context.Context.
+func InvokeContext(ctx context.Context, construct any) error {
+	return get().InvokeContext(ctx, construct)
+}
+
+// Call is the same as Invoke, but it also returns the primary result of the invoker, that is its first return value
+// that is not the error, casted to the provided generic type `R`. If it can't be casted it will return an error.
+func Call[R any](construct any) (R, error) {
+	return CallContext[R](context.Background(), construct)
+}
+
+// CallContext is the same as Call, but the dependency threes are resolved with the given context, in the same way as
+// InvokeContext does.
+func CallContext[R any](ctx context.Context, construct any) (R, error) {
+	result, err := get().CallContext(ctx, construct)
+
+	cast, ok := result.(R)
+	if !ok && result != nil {
+		return cast, fmt.Errorf("inject: error casting result of invoker to `%v`", reflect.TypeOf(&cast).Elem())
+	}
+
+	return cast, err
+}
+
 // Get is a wrapper over the Get function attached to the global container. This function modify the return type of the
 // resolved dependency, returned as `any` to the provided generic type `T`. If it can't be casted it will return an
 // error.
 func Get[T any, K symbolName](name K) (T, error) {
-	instance, err := get().Get(types.Symbol(name))
+	return GetContext[T](context.Background(), name)
+}
+
+// GetContext is the same as Get, but the dependency three is resolved with the given context. If the context is done
+// before the next factory is called, the build stops and the context error is returned.
+func GetContext[T any, K symbolName](ctx context.Context, name K) (T, error) {
+	instance, err := get().GetContext(ctx, types.Symbol(name))
 	if err != nil {
 		aux := new(T)
 		return *aux, err
@@ -87,30 +238,163 @@ func Get[T any, K symbolName](name K) (T, error) {
 	return cast, nil
 }
 
+// Resolve is a wrapper over the Resolve function attached to the global container. It returns the instance of the only
+// dependency provided for the type `T`, and fails if there is none or more than one.
+func Resolve[T any]() (T, error) {
+	rtype := reflect.TypeOf((*T)(nil)).Elem()
+
+	instance, err := get().Resolve(rtype)
+	if err != nil {
+		aux := new(T)
+		return *aux, err
+	}
+
+	cast, ok := instance.(T)
+	if !ok {
+		aux := new(T)
+		return *aux, fmt.Errorf("inject: error casting instance of `%v` dependency", rtype)
+	}
+
+	return cast, nil
+}
+
+// GetGroup is a wrapper over the GetGroup function attached to the global container. It returns an instance of each
+// member of the value group, casted to the provided generic type `T`. If any of them can't be casted it will return an
+// error.
+func GetGroup[T any, K symbolName](name K) ([]T, error) {
+	instances, err := get().GetGroup(types.Symbol(name))
+	if err != nil {
+		return nil, err
+	}
+
+	casts := make([]T, len(instances))
+
+	for i, instance := range instances {
+		cast, ok := instance.(T)
+		if !ok {
+			return nil, fmt.Errorf("inject: error casting member %d of `%s` group to `%v`", i, name, reflect.TypeOf((*T)(nil)).Elem())
+		}
+
+		casts[i] = cast
+	}
+
+	return casts, nil
+}
+
+// Populate is a wrapper over the Populate function attached to the global container. It fills the fields of an existing
+// struct, pointed by `target`, that have an `inject` tag. Untagged fields, and tagged fields that are unexported, are
+// left alone.
+func Populate(target any) error {
+	return get().Populate(target)
+}
+
+// PopulateStrict is the same as Populate, but it fails if a tagged field can't be set because it is unexported.
+func PopulateStrict(target any) error {
+	return get().PopulateStrict(target)
+}
+
+// Validate checks the whole dependency three of the global container without calling any factory, and returns every
+// problem found at once. It is useful to fail fast on application startup, or on a unit test.
+func Validate() error {
+	return get().Validate()
+}
+
+// Graph returns the description of how the dependencies of the global container are wired, that can be exported as
+// Graphviz DOT, Mermaid or JSON.
+func Graph() container.Graph {
+	return get().Graph()
+}
+
 // Flush WARNING: This function will delete all saved instances, solved and registered factories from the container.
 // Do not use this method on production, and just use it for testing purposes.
 func Flush() {
 	get().Flush()
 }
 
+// FlushAndClose is the same as Flush, but it closes every built singleton instance of the global container, in the
+// reverse order they were built, before deleting them.
+func FlushAndClose(ctx context.Context) error {
+	return get().FlushAndClose(ctx)
+}
+
+// Close releases every singleton instance built by the global container, in the reverse order they were built, calling
+// its `Close` method if it has one. After calling it the global container can't be used anymore, so it should only be
+// called on the application shutdown.
+func Close(ctx context.Context) error {
+	return get().Close(ctx)
+}
+
 // Dep is a Wrapper ver the dependency.Inject function to generify string symbol name.
 func Dep[T symbolName](name T) dependency.Injectable {
 	return dependency.Inject(types.Symbol(name))
 }
 
 func provide(name types.Symbol, singleton bool, factory any, args ...any) error {
-	if dep, ok := factory.(dependency.Dependency); ok {
-		dep.Singleton = singleton
-		return get().Provide(name, dep)
+	dep, err := newDependency(singleton, factory, args...)
+	if err != nil {
+		return err
 	}
 
-	if _, ok := factory.(dependency.Builder); ok {
-		return fmt.Errorf("factory parameter should be a function or a dependency.Dependency instance")
+	return get().Provide(name, dep)
+}
+
+// moduleDependency returns the dependency of a module registration, with the provide options found on the arguments
+// applied. Invalid factories are reported when the module is installed.
+func moduleDependency(factory any, args ...any) dependency.Dependency {
+	args, opts := splitOptions(args)
+
+	dep, ok := factory.(dependency.Dependency)
+	if !ok {
+		dep = dependency.New(factory, args...)
+	}
+
+	for _, opt := range opts {
+		opt(&dep)
+	}
+
+	return dep
+}
+
+// newDependency creates the dependency.Dependency that should be registered from a factory function and its arguments,
+// or from an already created dependency.Dependency, that is marked as a singleton if requested but is not changed
+// otherwise. Provide options found on the arguments are applied to the dependency in order.
+func newDependency(singleton bool, factory any, args ...any) (dependency.Dependency, error) {
+	args, opts := splitOptions(args)
+
+	dep, ok := factory.(dependency.Dependency)
+
+	if !ok {
+		if _, ok := factory.(dependency.Builder); ok {
+			return dependency.Dependency{}, fmt.Errorf("factory parameter should be a function or a dependency.Dependency instance")
+		}
+
+		dep = dependency.New(factory, args...)
 	}
 
 	if singleton {
-		return get().Provide(name, dependency.NewSingleton(factory, args...))
+		dep.Singleton = true
+	}
+
+	for _, opt := range opts {
+		opt(&dep)
+	}
+
+	return dep, nil
+}
+
+// splitOptions separates the provide options from the dependency arguments.
+func splitOptions(args []any) ([]any, []ProvideOption) {
+	deps := make([]any, 0, len(args))
+	opts := make([]ProvideOption, 0)
+
+	for _, arg := range args {
+		if opt, ok := arg.(ProvideOption); ok {
+			opts = append(opts, opt)
+			continue
+		}
+
+		deps = append(deps, arg)
 	}
 
-	return get().Provide(name, dependency.New(factory, args...))
+	return deps, opts
 }
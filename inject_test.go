@@ -1,6 +1,7 @@
 package inject
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -83,9 +84,14 @@ func TestGet(t *testing.T) {
 		ui, err := Get[*user](string(depName))
 		expErr := errors.New("inject: error building dependency instance: inject: error constructing `func(string, int) (*inject.user, error)`: some error")
 
+		var buildErr *BuildError
+
+		assert.ErrorAs(t, err, &buildErr)
+		assert.NotErrorIs(t, err, ErrNotFound)
+
 		assert.Error(t, err)
 		assert.Empty(t, ui)
-		assert.Equal(t, expErr, err)
+		assert.EqualError(t, err, expErr.Error())
 	})
 
 	t.Run("cast type error", func(t *testing.T) {
@@ -272,3 +278,447 @@ func TestProvide(t *testing.T) {
 		}
 	})
 }
+
+func TestResolve(t *testing.T) {
+	t.Run("resolve unnamed dependency by type", func(t *testing.T) {
+		defer Flush()
+
+		if err := SingletonType(newDB); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := ProvideType(newUserWithDB, dependency.New(newDB)); err != nil {
+			t.Error(err)
+			return
+		}
+
+		db, err := Resolve[*sql.DB]()
+		assert.NoError(t, err)
+		assert.NotNil(t, db)
+
+		db2, err := Resolve[*sql.DB]()
+		assert.NoError(t, err)
+		assert.Same(t, db, db2)
+
+		u, err := Resolve[*user]()
+		assert.NoError(t, err)
+		assert.NotNil(t, u.db)
+	})
+
+	t.Run("resolve type with no provider", func(t *testing.T) {
+		defer Flush()
+
+		u, err := Resolve[*user]()
+		expErr := errors.New("inject: no provided dependency of type `*inject.user`")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		assert.Error(t, err)
+		assert.Nil(t, u)
+		assert.EqualError(t, err, expErr.Error())
+	})
+}
+
+func TestGetGroup(t *testing.T) {
+	t.Run("get group members casted", func(t *testing.T) {
+		defer Flush()
+
+		if err := Provide("john", dependency.New(newUser, "John", 21).InGroup("users")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := Singleton("jane", dependency.New(newUser, "Jane", 22).InGroup("users")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		users, err := GetGroup[*user]("users")
+
+		if assert.NoError(t, err) && assert.Len(t, users, 2) {
+			assert.Equal(t, "John", users[0].name)
+			assert.Equal(t, "Jane", users[1].name)
+		}
+	})
+
+	t.Run("cast member type error", func(t *testing.T) {
+		defer Flush()
+
+		if err := Provide("john", dependency.New(newUser, "John", 21).InGroup("users")); err != nil {
+			t.Error(err)
+			return
+		}
+
+		users, err := GetGroup[string]("users")
+		expErr := errors.New("inject: error casting member 0 of `users` group to `string`")
+
+		assert.Error(t, err)
+		assert.Nil(t, users)
+		assert.Equal(t, expErr, err)
+	})
+}
+
+func TestScope(t *testing.T) {
+	defer Flush()
+
+	if err := Provide("user", dependency.NewScoped(newUser, name, age)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	scope := Scope("request")
+
+	u1, err := scope.Get("user")
+	assert.NoError(t, err)
+
+	u2, err := scope.Get("user")
+	assert.NoError(t, err)
+
+	u3, err := Scope("other").Get("user")
+	assert.NoError(t, err)
+
+	assert.Same(t, u1, u2)
+	assert.NotSame(t, u1, u3)
+}
+
+type closableDB struct {
+	closed bool
+}
+
+func (db *closableDB) Close() error {
+	db.closed = true
+	return nil
+}
+
+func TestFlushAndClose(t *testing.T) {
+	defer Flush()
+
+	if err := Singleton("db", func() *closableDB { return &closableDB{} }); err != nil {
+		t.Error(err)
+		return
+	}
+
+	db, err := Get[*closableDB]("db")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	assert.NoError(t, FlushAndClose(context.Background()))
+	assert.True(t, db.closed)
+}
+
+func TestValidate(t *testing.T) {
+	defer Flush()
+
+	if err := Provide("user", newUserWithDB, Dep("db")); err != nil {
+		t.Error(err)
+		return
+	}
+
+	err := Validate()
+
+	assert.EqualError(t, err, "inject: invalid dependency `user`: inject: error resolving argument 0 for constructor func(*sql.DB) *inject.user: inject: no provided dependency of name `db`")
+
+	if err := Singleton("db", newDB); err != nil {
+		t.Error(err)
+		return
+	}
+
+	assert.NoError(t, Validate())
+}
+
+func TestGraph(t *testing.T) {
+	defer Flush()
+
+	if err := Singleton("db", newDB); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := Provide("user", newUserWithDB, Dep("db")); err != nil {
+		t.Error(err)
+		return
+	}
+
+	g := Graph()
+
+	assert.Len(t, g.Nodes, 2)
+	assert.Equal(t, []container.GraphEdge{{From: "user", To: "db", Argument: 0}}, g.Edges)
+}
+
+func TestDecorate(t *testing.T) {
+	defer Flush()
+
+	if err := Provide("user", newUser, name, age); err != nil {
+		t.Error(err)
+		return
+	}
+
+	err := Decorate("user", func(u *user, suffix string) *user {
+		u.name += suffix
+		return u
+	}, " Smith")
+
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	u, err := Get[*user]("user")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "John Smith", u.name)
+}
+
+func TestReplace(t *testing.T) {
+	defer Flush()
+
+	if err := Singleton("user", newUser, name, age); err != nil {
+		t.Error(err)
+		return
+	}
+
+	snap := Snapshot()
+
+	t.Run("override with mock", func(t *testing.T) {
+		t.Cleanup(func() { Restore(snap) })
+
+		if err := Replace("user", dependency.NewSingleton(newUser, "Mock", 0)); err != nil {
+			t.Error(err)
+			return
+		}
+
+		u, err := Get[*user]("user")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Mock", u.name)
+	})
+
+	u, err := Get[*user]("user")
+
+	assert.NoError(t, err)
+	assert.Equal(t, name, u.name)
+}
+
+func TestCall(t *testing.T) {
+	defer Flush()
+
+	if err := Provide("user", newUser, name, age); err != nil {
+		t.Error(err)
+		return
+	}
+
+	u, err := Call[*user](func(in struct {
+		types.In
+		User *user `inject:"name=user"`
+	}) (*user, error) {
+		return in.User, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, name, u.name)
+
+	_, err = Call[string](func() int { return 1 })
+
+	assert.EqualError(t, err, "inject: error casting result of invoker to `string`")
+}
+
+func TestGetContext(t *testing.T) {
+	defer Flush()
+
+	type ctxKey struct{}
+
+	if err := Provide("user", func(ctx context.Context, age int) *user {
+		return newUser(ctx.Value(ctxKey{}).(string), age)
+	}, age); err != nil {
+		t.Error(err)
+		return
+	}
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, name)
+
+	u, err := GetContext[*user](ctx, "user")
+
+	assert.NoError(t, err)
+	assert.Equal(t, name, u.name)
+
+	err = InvokeContext(ctx, func(ctx context.Context) {
+		assert.Equal(t, name, ctx.Value(ctxKey{}))
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestOut(t *testing.T) {
+	defer Flush()
+
+	type users struct {
+		types.Out
+		Admin *user `inject:"name=users.admin"`
+		Guest *user `inject:"name=users.guest"`
+	}
+
+	if err := Singleton("users", func() users {
+		return users{Admin: newUser(name, age), Guest: newUser("Guest", 0)}
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	admin, err := Get[*user]("users.admin")
+
+	assert.NoError(t, err)
+	assert.Equal(t, name, admin.name)
+
+	guest, err := Get[*user]("users.guest")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Guest", guest.name)
+}
+
+func TestMultiOutput(t *testing.T) {
+	defer Flush()
+
+	calls := 0
+
+	if err := Provide("users.admin", func() (*user, *user, error) {
+		calls++
+		return newUser(name, age), newUser("Guest", 0), nil
+	}, WithOutputNames("users.guest")); err != nil {
+		t.Error(err)
+		return
+	}
+
+	err := Invoke(func(in struct {
+		types.In
+		Admin *user `inject:"name=users.admin"`
+		Guest *user `inject:"name=users.guest"`
+	}) {
+		assert.Equal(t, name, in.Admin.name)
+		assert.Equal(t, "Guest", in.Guest.name)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestPopulate(t *testing.T) {
+	defer Flush()
+
+	if err := Provide("user", newUser, name, age); err != nil {
+		t.Error(err)
+		return
+	}
+
+	type suite struct {
+		User *user `inject:"name=user"`
+		name string
+	}
+
+	s := &suite{name: "suite"}
+
+	err := Populate(s)
+
+	if assert.NoError(t, err) && assert.NotNil(t, s.User) {
+		assert.Equal(t, name, s.User.name)
+		assert.Equal(t, "suite", s.name)
+	}
+
+	type strictSuite struct {
+		user *user `inject:"name=user"`
+	}
+
+	err = PopulateStrict(&strictSuite{})
+
+	assert.Error(t, err)
+}
+
+func TestInstall(t *testing.T) {
+	defer Flush()
+
+	users := Module("users",
+		Private("name", func() string { return name }),
+		Register("admin", newUser, Dep("name"), age),
+	)
+
+	if err := Install(users); err != nil {
+		t.Error(err)
+		return
+	}
+
+	u, err := Get[*user]("users.admin")
+
+	assert.NoError(t, err)
+	assert.Equal(t, name, u.name)
+
+	_, err = Get[string]("users.name")
+
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	err = Install(users)
+
+	var moduleErr *ModuleError
+
+	if assert.ErrorAs(t, err, &moduleErr) {
+		assert.Equal(t, "users", moduleErr.Module)
+	}
+}
+
+func TestProvideOptions(t *testing.T) {
+	defer Flush()
+
+	if err := Provide("user", newUser, name, WithSingleton(), age, WithDescription("the user")); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := Provide("built", dependency.NewSingleton(newUser, name, age)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, depName := range []string{"user", "built"} {
+		u1, err := Get[*user](depName)
+		assert.NoError(t, err)
+
+		u2, err := Get[*user](depName)
+		assert.NoError(t, err)
+
+		assert.Same(t, u1, u2)
+	}
+
+	assert.Equal(t, "the user", Graph().Nodes[1].Description)
+}
+
+func TestKey(t *testing.T) {
+	defer Flush()
+
+	userKey := NewKey[*user]("user")
+
+	if err := ProvideKey(userKey, newUser, name, age); err != nil {
+		t.Error(err)
+		return
+	}
+
+	u, err := GetKey(userKey)
+
+	assert.NoError(t, err)
+	assert.Equal(t, name, u.name)
+
+	err = ProvideKey(NewKey[*sql.DB]("db"), newUser, name, age)
+
+	assert.EqualError(t, err, "inject: factory of key `db` should return *sql.DB, got `*inject.user`")
+
+	if err := Provide("name", func(u *user) string { return u.name }, dependency.InjectKey(userKey)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	n, err := Get[string]("name")
+
+	assert.NoError(t, err)
+	assert.Equal(t, name, n)
+	assert.NoError(t, Validate())
+}
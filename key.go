@@ -0,0 +1,49 @@
+package inject
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Drafteame/inject/types"
+	"github.com/Drafteame/inject/utils"
+)
+
+// NewKey creates a key for the dependency of the given name, whose instances are of type T. The key should be declared
+// once next to the provider of the dependency, and used to provide, get and inject it, e.g:
+//
+//	var UserKey = inject.NewKey[*User]("user")
+//
+//	_ = inject.ProvideKey(UserKey, newUser)
+//	_ = inject.Provide("profile", newProfile, dependency.InjectKey(UserKey))
+//
+//	user, err := inject.GetKey(UserKey)
+func NewKey[T any, K symbolName](name K) types.Key[T] {
+	return types.NewKey[T](types.Symbol(name))
+}
+
+// ProvideKey Is the same as Provide, but the dependency is provided with the name of the key, and it fails if the
+// factory doesn't return instances of the key type.
+func ProvideKey[T any](key types.Key[T], factory any, args ...any) error {
+	dep, err := newDependency(false, factory, args...)
+	if err != nil {
+		return err
+	}
+
+	rt := utils.GetFirstReturnType(dep.Factory)
+
+	if rt == nil || !rt.AssignableTo(key.Type()) {
+		return fmt.Errorf("inject: factory of key `%s` should return %v, got `%v`", key.Name(), key.Type(), rt)
+	}
+
+	return get().Provide(key.Name(), dep)
+}
+
+// GetKey Is the same as Get, but the dependency is resolved by the name of the key and returned as the key type.
+func GetKey[T any](key types.Key[T]) (T, error) {
+	return GetContext[T](context.Background(), key.Name())
+}
+
+// GetKeyContext Is the same as GetKey, but the dependency three is resolved with the given context.
+func GetKeyContext[T any](ctx context.Context, key types.Key[T]) (T, error) {
+	return GetContext[T](ctx, key.Name())
+}
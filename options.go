@@ -0,0 +1,44 @@
+package inject
+
+import (
+	"context"
+
+	"github.com/Drafteame/inject/container"
+	"github.com/Drafteame/inject/types"
+)
+
+// ProvideOption configures a dependency when it is provided. Options can be passed along with the dependency arguments
+// to `inject.Provide` and the rest of the provide helpers.
+type ProvideOption = container.ProvideOption
+
+// WithSingleton marks the dependency as a singleton, so it is built only once and shared.
+func WithSingleton() ProvideOption {
+	return container.WithSingleton()
+}
+
+// WithAs binds the dependency to the interfaces pointed by the provided values, so it can be resolved by any of them.
+func WithAs(ifaces ...any) ProvideOption {
+	return container.WithAs(ifaces...)
+}
+
+// WithGroup adds the dependency to the provided value groups.
+func WithGroup(groups ...types.Symbol) ProvideOption {
+	return container.WithGroup(groups...)
+}
+
+// WithOnClose sets the function that releases the built instances of the dependency when the container is closed,
+// instead of their own `Close` method.
+func WithOnClose[T any](fn func(ctx context.Context, instance T) error) ProvideOption {
+	return container.WithOnClose(fn)
+}
+
+// WithDescription sets a human readable description of the dependency, that is shown on the dependency graph.
+func WithDescription(description string) ProvideOption {
+	return container.WithDescription(description)
+}
+
+// WithOutputNames names the outputs of a multi-output factory after the first one, in order. Outputs left without a
+// name are registered by their type.
+func WithOutputNames(names ...types.Symbol) ProvideOption {
+	return container.WithOutputNames(names...)
+}
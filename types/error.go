@@ -1,3 +1,217 @@
 package types
 
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
 type Error error
+
+var (
+	// ErrNotFound is matched by errors.Is when a dependency was not provided, by name or by type.
+	ErrNotFound = errors.New("inject: dependency not found")
+
+	// ErrDuplicate is matched by errors.Is when a dependency name was already provided.
+	ErrDuplicate = errors.New("inject: duplicated dependency")
+
+	// ErrAmbiguous is matched by errors.Is when a type is resolved and more than one dependency was provided for it.
+	ErrAmbiguous = errors.New("inject: ambiguous dependency")
+
+	// ErrCycle is matched by errors.Is when a dependency needs itself to be built.
+	ErrCycle = errors.New("inject: dependency cycle")
+
+	// ErrClosed is returned by every operation of a container that was already closed.
+	ErrClosed = errors.New("inject: container is closed")
+)
+
+// NotFoundError is returned when a dependency was not provided. If the dependency was resolved by type, Type holds
+// that type and Name is empty.
+type NotFoundError struct {
+	Name Symbol
+	Type reflect.Type
+}
+
+func (e *NotFoundError) Error() string {
+	if e.Type != nil {
+		return fmt.Sprintf("inject: no provided dependency of type `%v`", e.Type)
+	}
+
+	return fmt.Sprintf("inject: no provided dependency of name `%s`", e.Name)
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// DuplicateError is returned when a dependency is provided with a name that is already taken.
+type DuplicateError struct {
+	Name Symbol
+}
+
+func (e *DuplicateError) Error() string {
+	return fmt.Sprintf("inject: duplicated dependency name `%s`", e.Name)
+}
+
+func (e *DuplicateError) Is(target error) bool {
+	return target == ErrDuplicate
+}
+
+// AmbiguousError is returned when a type is resolved and there is more than one dependency provided for it. Names holds
+// every candidate.
+type AmbiguousError struct {
+	Type  reflect.Type
+	Names []Symbol
+}
+
+func (e *AmbiguousError) Error() string {
+	quoted := make([]string, len(e.Names))
+
+	for i, name := range e.Names {
+		quoted[i] = fmt.Sprintf("`%s`", name)
+	}
+
+	return fmt.Sprintf("inject: multiple provided dependencies of type `%v`: %s", e.Type, strings.Join(quoted, ", "))
+}
+
+func (e *AmbiguousError) Is(target error) bool {
+	return target == ErrAmbiguous
+}
+
+// CycleError is returned when a dependency needs itself to be built, directly or through other dependencies. Path holds
+// the whole resolution chain, so it starts and ends on the same symbol.
+type CycleError struct {
+	Path []Symbol
+}
+
+func (e *CycleError) Error() string {
+	names := make([]string, len(e.Path))
+
+	for i, name := range e.Path {
+		names[i] = string(name)
+	}
+
+	return "inject: dependency cycle detected: " + strings.Join(names, " -> ")
+}
+
+func (e *CycleError) Is(target error) bool {
+	return target == ErrCycle
+}
+
+// BuildError is returned when a provided dependency can't be built. It carries the name of the dependency, the type of
+// its factory and the resolution path that lead to it, starting on the dependency that was requested and ending on
+// Symbol. The underlying error, that can be returned by the factory itself, can be reached with errors.Is and
+// errors.As.
+type BuildError struct {
+	Symbol  Symbol
+	Factory reflect.Type
+	Path    []Symbol
+	Err     error
+}
+
+func (e *BuildError) Error() string {
+	return fmt.Sprintf("inject: error building dependency instance: %v", e.Err)
+}
+
+func (e *BuildError) Unwrap() error {
+	return e.Err
+}
+
+// ModuleError is returned when a module can't be installed on a container. Module holds the name of the module that
+// caused the problem, and the underlying error can be reached with errors.Is and errors.As.
+type ModuleError struct {
+	Module string
+	Err    error
+}
+
+func (e *ModuleError) Error() string {
+	return fmt.Sprintf("inject: error installing module `%s`: %v", e.Module, e.Err)
+}
+
+func (e *ModuleError) Unwrap() error {
+	return e.Err
+}
+
+// DefinitionError is returned when a definition file can't be loaded on a container. It points at the file, the line
+// and the key of the definition that caused the problem, and the underlying error can be reached with errors.Is and
+// errors.As.
+type DefinitionError struct {
+	File string
+	Line int
+	Key  string
+	Err  error
+}
+
+func (e *DefinitionError) Error() string {
+	pos := fmt.Sprintf("line %d", e.Line)
+
+	if e.File != "" {
+		pos = fmt.Sprintf("%s:%d", e.File, e.Line)
+	}
+
+	if e.Key == "" {
+		return fmt.Sprintf("inject: %s: %v", pos, e.Err)
+	}
+
+	return fmt.Sprintf("inject: %s: error on key `%s`: %v", pos, e.Key, e.Err)
+}
+
+func (e *DefinitionError) Unwrap() error {
+	return e.Err
+}
+
+// Errors groups the errors collected by an operation that does not stop on the first failure.
+type Errors []error
+
+func (e Errors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+
+	msgs := make([]string, len(e))
+
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return fmt.Sprintf("inject: %d errors occurred: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the grouped errors, so `errors.Is` and `errors.As` can look into each of them.
+func (e Errors) Unwrap() []error {
+	return e
+}
+
+// Is returns true if any of the grouped errors matches the target. `errors.Is` only looks into the errors returned by
+// Unwrap from go 1.20, so it is also done here for older versions.
+func (e Errors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first of the grouped errors that matches the target, and sets the target to it. It is the same as Is,
+// but for `errors.As`.
+func (e Errors) As(target any) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ErrOrNil returns nil if there are no errors, or the Errors list otherwise.
+func (e Errors) ErrOrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+
+	return e
+}
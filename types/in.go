@@ -1,6 +1,7 @@
 package types
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
@@ -11,27 +12,49 @@ import (
 const (
 	tag            = "inject"
 	nameOption     = "name"
+	groupOption    = "group"
 	optionalOption = "optional"
 )
 
+// Container is the source of the dependencies that are used to fill the fields of an In struct.
 type Container interface {
 	Get(name Symbol) (any, error)
+	Resolve(rtype reflect.Type) (any, error)
+	GetGroup(name Symbol) ([]any, error)
 }
 
+// contextContainer is a Container that knows the context of the current resolution.
+type contextContainer interface {
+	Context() context.Context
+}
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
 // In is a struct that should be embedded to other struct to denote that is a valid input for an invoker function and
 // his fields should be filled from the dependency container threes.
 type In struct{}
 
-// injectInField  is the configuration that each In struct fields should follow to be filled.
+var inType = reflect.TypeOf(In{})
+
+// injectInField  is the configuration that each In struct fields should follow to be filled. The name of the fields of
+// nested In structs is the full path from the top level struct, and a nested In struct has the configuration of each
+// of its own fields.
 type injectInField struct {
 	fieldName  string
+	fieldIndex []int
+	fieldType  reflect.Type
 	injectName Symbol
+	group      Symbol
 	optional   bool
 	container  Container
+	nested     bool
+	fields     []injectInField
 }
 
+// BuildIn fills every field of the In struct from the container. Embedded structs, and struct fields with no name nor
+// group tag, that also embed the In struct are filled recursively.
 func BuildIn(cont Container, in reflect.Value) error {
-	if !utils.EmbedsType(in.Type(), reflect.TypeOf(In{})) {
+	if !utils.EmbedsType(in.Type(), inType) {
 		return fmt.Errorf("inject: struct doesn't embed `inject.In` struct")
 	}
 
@@ -40,25 +63,104 @@ func BuildIn(cont Container, in reflect.Value) error {
 		itype = itype.Elem()
 	}
 
-	nfields := itype.NumField()
+	injectFields, err := buildInjectInFields(cont, itype, "", []reflect.Type{itype})
+	if err != nil {
+		return err
+	}
+
+	return fillInStruct(cont, in, injectFields)
+}
+
+// buildInjectInFields returns the configuration of every field of the In struct type. Fields of nested In structs are
+// named with the path of the struct that holds them as prefix, and `visited` holds the In structs of that path, so a
+// struct can't be nested on itself.
+func buildInjectInFields(cont Container, itype reflect.Type, prefix string, visited []reflect.Type) ([]injectInField, error) {
 	injectFields := make([]injectInField, 0)
 
-	for i := 0; i < nfields; i++ {
-		if itype.Field(i).Anonymous {
+	for i := 0; i < itype.NumField(); i++ {
+		field := itype.Field(i)
+		field.Name = prefix + field.Name
+
+		if field.Type == inType {
 			continue
 		}
 
-		injectField, err := buildInjectInField(itype.Field(i))
+		// Unexported fields can't be set, so untagged ones are left alone, as they may not be meant to be injected.
+		if !field.Anonymous && !field.IsExported() {
+			if _, ok := field.Tag.Lookup(tag); ok {
+				return nil, fmt.Errorf("inject: can't set unexported field `%s` of `%v`", field.Name, itype)
+			}
+
+			continue
+		}
+
+		if isNestedIn(field) {
+			stype := field.Type
+			if stype.Kind() == reflect.Ptr {
+				stype = stype.Elem()
+			}
+
+			for _, v := range visited {
+				if v == stype {
+					return nil, fmt.Errorf("inject: In struct `%v` is nested on itself on field `%s`", stype, field.Name)
+				}
+			}
+
+			fields, err := buildInjectInFields(cont, stype, field.Name+".", append(visited, stype))
+			if err != nil {
+				return nil, err
+			}
+
+			injectFields = append(injectFields, injectInField{
+				fieldName:  field.Name,
+				fieldIndex: field.Index,
+				fieldType:  field.Type,
+				container:  cont,
+				fields:     fields,
+			})
+
+			continue
+		}
+
+		if field.Anonymous {
+			continue
+		}
+
+		injectField, err := buildInjectInField(field)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		injectField.container = cont
+		injectField.nested = prefix != ""
 
 		injectFields = append(injectFields, injectField)
 	}
 
-	return fillInStruct(cont, in, injectFields)
+	return injectFields, nil
+}
+
+// isNestedIn returns true if the field is a struct, or a pointer to a struct, that embeds the In struct and should be
+// filled recursively. Fields tagged with a name or a group are dependencies themselves, so they are not nested.
+func isNestedIn(field reflect.StructField) bool {
+	stype := field.Type
+	if stype.Kind() == reflect.Ptr {
+		stype = stype.Elem()
+	}
+
+	if stype.Kind() != reflect.Struct || !utils.EmbedsType(stype, inType) {
+		return false
+	}
+
+	if field.Anonymous {
+		return true
+	}
+
+	ftags := getFieldTags(field)
+	_, named := ftags[nameOption]
+	_, grouped := ftags[groupOption]
+
+	return !named && !grouped
 }
 
 // fillInStruct It iterates over the `conf` array. For each element in the array, it calls a function based on the value
@@ -67,7 +169,15 @@ func BuildIn(cont Container, in reflect.Value) error {
 // errors occur, then nil is returned.
 func fillInStruct(cont Container, in reflect.Value, conf []injectInField) error {
 	for _, inject := range conf {
-		if err := fillStructFieldFromBuilder(cont, in, inject); err != nil {
+		fill := fillStructFieldFromBuilder
+
+		if inject.fields != nil {
+			fill = fillStructFieldFromNested
+		} else if inject.group != "" {
+			fill = fillStructFieldFromGroup
+		}
+
+		if err := fill(cont, in, inject); err != nil {
 			return err
 		}
 	}
@@ -75,45 +185,168 @@ func fillInStruct(cont Container, in reflect.Value, conf []injectInField) error
 	return nil
 }
 
+// fillStructFieldFromNested It fills the nested In struct of the field recursively. If the field is a pointer, a new
+// struct is allocated for it.
+func fillStructFieldFromNested(cont Container, in reflect.Value, conf injectInField) error {
+	field := structValue(in).FieldByIndex(conf.fieldIndex)
+
+	if field.Kind() != reflect.Ptr {
+		return fillInStruct(cont, field, conf.fields)
+	}
+
+	nested := reflect.New(conf.fieldType.Elem())
+
+	if err := fillInStruct(cont, nested, conf.fields); err != nil {
+		return err
+	}
+
+	field.Set(nested)
+
+	return nil
+}
+
+// structValue returns the struct value pointed by `in`, or `in` itself if it is not a pointer.
+func structValue(in reflect.Value) reflect.Value {
+	if in.Kind() == reflect.Ptr {
+		return in.Elem()
+	}
+
+	return in
+}
+
+// fieldError adds the path of a field of a nested In struct to an error returned by the container, so it can be told
+// apart from the same dependency injected on other fields.
+func fieldError(conf injectInField, err error) error {
+	if !conf.nested {
+		return err
+	}
+
+	return fmt.Errorf("inject: error filling field `%s`: %w", conf.fieldName, err)
+}
+
 // fillStructFieldFromBuilder It checks if the dependency exists. If it doesn't exist, it returns an error. It builds the
-// dependency using `builder`. It sets the field of the struct with name `conf.fieldName` to be equal to `out`. Returns
-// nil (no error).
+// dependency using `builder`, by its name or by the field type if no name was provided. It sets the field of the
+// struct with name `conf.fieldName` to be equal to `out`. Returns nil (no error).
 func fillStructFieldFromBuilder(cont Container, in reflect.Value, conf injectInField) error {
-	val, err := cont.Get(conf.injectName)
+	var val any
+	var err error
+
+	if InjectsLazy(cont, conf.injectName, conf.fieldType) {
+		return fillStructFieldFromLazy(cont, in, conf)
+	}
+
+	if conf.injectName != "" {
+		val, err = cont.Get(conf.injectName)
+	} else if conf.fieldType == contextType {
+		val = contextOf(cont)
+	} else {
+		val, err = cont.Resolve(conf.fieldType)
+	}
+
 	if err != nil {
 		if conf.optional {
 			return nil
 		}
 
-		return err
+		return fieldError(conf, err)
 	}
 
-	invalue := in
+	field := structValue(in).FieldByIndex(conf.fieldIndex)
 
-	if invalue.Kind() == reflect.Ptr {
-		invalue = invalue.Elem()
+	if val == nil {
+		field.Set(reflect.Zero(conf.fieldType))
+		return nil
 	}
 
-	field := invalue.FieldByName(conf.fieldName)
+	if vtype := reflect.TypeOf(val); !vtype.AssignableTo(conf.fieldType) {
+		return fmt.Errorf("inject: using %v as type %v for field `%s`", vtype, conf.fieldType, conf.fieldName)
+	}
 
 	field.Set(reflect.ValueOf(val))
 	return nil
 }
 
-// buildInjectInField We get the tags of the field. If there is a `name` tag, we set the source to `sourceNamedDeps`. If
-// there is an `optional` tag, we set it to true. We create a new injectInField struct and return it.
+// fillStructFieldFromLazy It sets the field of the struct with name `conf.fieldName` to a lazy resolver of the
+// dependency, by its name or by the lazy value type if no name was provided. Nothing is built until it is called.
+func fillStructFieldFromLazy(cont Container, in reflect.Value, conf injectInField) error {
+	lazyCont := detach(cont)
+	vtype := conf.fieldType.Out(0)
+
+	resolve := func() (any, error) {
+		if conf.injectName != "" {
+			return lazyCont.Get(conf.injectName)
+		}
+
+		return lazyCont.Resolve(vtype)
+	}
+
+	structValue(in).FieldByIndex(conf.fieldIndex).Set(NewLazy(conf.fieldType, resolve))
+
+	return nil
+}
+
+// contextOf returns the context of the current resolution, or a background context if the container doesn't provide it.
+func contextOf(cont Container) context.Context {
+	if cc, ok := cont.(contextContainer); ok && cc.Context() != nil {
+		return cc.Context()
+	}
+
+	return context.Background()
+}
+
+// fillStructFieldFromGroup It resolves every member of the group and checks that each of them can be assigned to the
+// element type of the slice field. It sets the field of the struct with name `conf.fieldName` to a new slice with all
+// the members, in the order they were provided.
+func fillStructFieldFromGroup(cont Container, in reflect.Value, conf injectInField) error {
+	vals, err := cont.GetGroup(conf.group)
+	if err != nil {
+		return fieldError(conf, err)
+	}
+
+	etype := conf.fieldType.Elem()
+	slice := reflect.MakeSlice(conf.fieldType, 0, len(vals))
+
+	for i, val := range vals {
+		if val == nil {
+			slice = reflect.Append(slice, reflect.Zero(etype))
+			continue
+		}
+
+		vtype := reflect.TypeOf(val)
+
+		if !vtype.AssignableTo(etype) {
+			return fmt.Errorf("inject: using %v as type %v on member %d of group `%s` for field `%s`", vtype, etype, i, conf.group, conf.fieldName)
+		}
+
+		slice = reflect.Append(slice, reflect.ValueOf(val))
+	}
+
+	structValue(in).FieldByIndex(conf.fieldIndex).Set(slice)
+
+	return nil
+}
+
+// buildInjectInField We get the tags of the field. If there is a `name` tag, the field will be filled by that name,
+// otherwise it will be filled by the field type. If there is an `optional` tag, we set it to true. We create a new
+// injectInField struct and return it. If there is a `group` tag, the field should be a slice and it will be filled
+// with every member of the group.
 func buildInjectInField(field reflect.StructField) (injectInField, error) {
 	ftags := getFieldTags(field)
-	injectName := ""
 	optional := false
 
-	val, ok := ftags[nameOption]
+	injectName, ok := ftags[nameOption]
 
-	if !ok {
-		return injectInField{}, fmt.Errorf("inject: missing name tag of inject dependency on field `%s`", field.Name)
+	if ok && injectName == "" {
+		return injectInField{}, fmt.Errorf("inject: empty name tag of inject dependency on field `%s`", field.Name)
 	}
 
-	injectName = val
+	group, isGroup := ftags[groupOption]
+
+	if isGroup {
+		if err := checkGroupField(field, ok, group); err != nil {
+			return injectInField{}, err
+		}
+	}
 
 	if _, ok := ftags[optionalOption]; ok {
 		optional = true
@@ -121,13 +354,33 @@ func buildInjectInField(field reflect.StructField) (injectInField, error) {
 
 	inject := injectInField{
 		fieldName:  field.Name,
+		fieldIndex: field.Index,
+		fieldType:  field.Type,
 		injectName: Symbol(injectName),
+		group:      Symbol(group),
 		optional:   optional,
 	}
 
 	return inject, nil
 }
 
+// checkGroupField validates the configuration of a field tagged with the `group` option.
+func checkGroupField(field reflect.StructField, named bool, group string) error {
+	if group == "" {
+		return fmt.Errorf("inject: empty group tag of inject dependency on field `%s`", field.Name)
+	}
+
+	if named {
+		return fmt.Errorf("inject: name and group tags can't be used together on field `%s`", field.Name)
+	}
+
+	if field.Type.Kind() != reflect.Slice {
+		return fmt.Errorf("inject: group field `%s` should be a slice, got `%v`", field.Name, field.Type)
+	}
+
+	return nil
+}
+
 // getFieldTags It gets the tag value from the field. If there is no tag, it returns an empty map. It splits the tag by
 // commas and trims spaces from each part of the split result. It creates a map to store all tags and their values (if
 // any). For each part of the split result:
@@ -0,0 +1,31 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Key is the name of a dependency along with the type of its instances. It should be declared once next to the provider
+// of the dependency, so lookups and injections made with it are checked against that type.
+type Key[T any] struct {
+	name Symbol
+}
+
+// NewKey creates a key for the dependency of the given name, whose instances are of type T.
+func NewKey[T any](name Symbol) Key[T] {
+	return Key[T]{name: name}
+}
+
+// Name returns the name of the dependency.
+func (k Key[T]) Name() Symbol {
+	return k.name
+}
+
+// Type returns the type of the dependency instances.
+func (k Key[T]) Type() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func (k Key[T]) String() string {
+	return fmt.Sprintf("%s(%v)", k.name, k.Type())
+}
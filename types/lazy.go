@@ -0,0 +1,88 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Lazy is a function that resolves a dependency when it is called instead of when it is injected. A field of an In
+// struct, or a factory parameter that receives a `dependency.Inject` argument, of type Lazy[T], or of any function type
+// with the same signature like `func() (T, error)`, receives a resolver bound to the dependency instead of its value.
+// A dependency whose value is already a function of that type is injected as it is.
+//
+// Each call resolves the dependency again, so a singleton is built on the first call and a non-singleton dependency is
+// built on every call.
+type Lazy[T any] func() (T, error)
+
+// Detacher is a Container that can start a new top level resolution. Lazy resolvers use it, since they can be called
+// after the resolution that created them finished.
+type Detacher interface {
+	Detach() Container
+}
+
+// Typer is a Container that knows the type of the provided dependencies without building them. Lazy types are
+// checked with it, so a dependency whose value is already a function of that type is injected as it is.
+type Typer interface {
+	DependencyType(name Symbol) (reflect.Type, bool)
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// IsLazy returns true if the provided type is a function with no parameters that returns a value and an error, like
+// Lazy[T].
+func IsLazy(rtype reflect.Type) bool {
+	return rtype != nil && rtype.Kind() == reflect.Func && rtype.NumIn() == 0 && rtype.NumOut() == 2 &&
+		rtype.Out(1) == errorType
+}
+
+// InjectsLazy returns true if the dependency of the given name should be injected on a field or parameter of type
+// `rtype` as a lazy resolver. That is the case when the type is lazy, unless the container is a Typer and the value of
+// the dependency can be assigned to the type as it is.
+func InjectsLazy(cont any, name Symbol, rtype reflect.Type) bool {
+	if !IsLazy(rtype) {
+		return false
+	}
+
+	if t, ok := cont.(Typer); ok && name != "" {
+		if dtype, ok := t.DependencyType(name); ok && dtype != nil && dtype.AssignableTo(rtype) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NewLazy creates a function of the given lazy type that calls `resolve` every time it is called, and checks that the
+// resolved value can be returned as the lazy value type.
+func NewLazy(ltype reflect.Type, resolve func() (any, error)) reflect.Value {
+	vtype := ltype.Out(0)
+
+	return reflect.MakeFunc(ltype, func([]reflect.Value) []reflect.Value {
+		res := reflect.New(vtype).Elem()
+		err := reflect.New(errorType).Elem()
+
+		val, rerr := resolve()
+
+		switch {
+		case rerr != nil:
+			err.Set(reflect.ValueOf(&rerr).Elem())
+		case val == nil:
+		case !reflect.TypeOf(val).AssignableTo(vtype):
+			cerr := fmt.Errorf("inject: using %v as type %v on lazy dependency", reflect.TypeOf(val), vtype)
+			err.Set(reflect.ValueOf(&cerr).Elem())
+		default:
+			res.Set(reflect.ValueOf(val))
+		}
+
+		return []reflect.Value{res, err}
+	})
+}
+
+// detach returns the container that lazy resolvers should use.
+func detach(cont Container) Container {
+	if d, ok := cont.(Detacher); ok {
+		return d.Detach()
+	}
+
+	return cont
+}
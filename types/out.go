@@ -0,0 +1,85 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/Drafteame/inject/utils"
+)
+
+// Out is a struct that should be embedded to the struct returned by a factory to denote that each of its fields tagged
+// with a name should be registered as its own dependency, e.g:
+//
+//	type Configs struct {
+//		types.Out
+//		DB   DBConfig   `inject:"name=config.db"`
+//		HTTP HTTPConfig `inject:"name=config.http"`
+//	}
+//
+// Every field dependency is backed by the same build of the factory.
+type Out struct{}
+
+// OutField is a field of an Out struct that is registered as its own dependency.
+type OutField struct {
+	Name  Symbol
+	Type  reflect.Type
+	Index []int
+}
+
+// IsOut returns true if the provided type is a struct, or a pointer to a struct, that embeds the Out struct.
+func IsOut(rtype reflect.Type) bool {
+	if rtype == nil {
+		return false
+	}
+
+	stype := rtype
+	if stype.Kind() == reflect.Ptr {
+		stype = stype.Elem()
+	}
+
+	if stype.Kind() != reflect.Struct {
+		return false
+	}
+
+	return utils.EmbedsType(stype, reflect.TypeOf(Out{}))
+}
+
+// OutFields returns every field of the Out struct that is tagged with a name. Untagged and anonymous fields are
+// ignored, and tagged fields should be exported so they can be read.
+func OutFields(rtype reflect.Type) ([]OutField, error) {
+	if !IsOut(rtype) {
+		return nil, fmt.Errorf("inject: struct doesn't embed `inject.Out` struct")
+	}
+
+	stype := rtype
+	if stype.Kind() == reflect.Ptr {
+		stype = stype.Elem()
+	}
+
+	fields := make([]OutField, 0)
+
+	for i := 0; i < stype.NumField(); i++ {
+		field := stype.Field(i)
+
+		if field.Anonymous {
+			continue
+		}
+
+		name, ok := getFieldTags(field)[nameOption]
+		if !ok {
+			continue
+		}
+
+		if name == "" {
+			return nil, fmt.Errorf("inject: empty name tag of output dependency on field `%s`", field.Name)
+		}
+
+		if !field.IsExported() {
+			return nil, fmt.Errorf("inject: output field `%s` should be exported", field.Name)
+		}
+
+		fields = append(fields, OutField{Name: Symbol(name), Type: field.Type, Index: field.Index})
+	}
+
+	return fields, nil
+}
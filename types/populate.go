@@ -0,0 +1,48 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Populate fills the fields of an existing struct, pointed by `target`, that have an `inject` tag. The tag options are
+// the same as the ones of an In struct, and a field tagged with no name nor group is filled by its type. Untagged and
+// anonymous fields are left alone, and the struct doesn't need to embed the In struct.
+//
+// Unexported fields can't be set, so tagged unexported fields are skipped, unless `strict` is true, in which case an
+// error is returned.
+func Populate(cont Container, target reflect.Value, strict bool) error {
+	if target.Kind() != reflect.Ptr || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("inject: populate target should be a non nil pointer to a struct, got `%v`", target.Type())
+	}
+
+	stype := target.Type().Elem()
+	injectFields := make([]injectInField, 0)
+
+	for i := 0; i < stype.NumField(); i++ {
+		field := stype.Field(i)
+
+		if _, ok := field.Tag.Lookup(tag); !ok || field.Anonymous {
+			continue
+		}
+
+		if !field.IsExported() {
+			if strict {
+				return fmt.Errorf("inject: can't set unexported field `%s` of `%v`", field.Name, stype)
+			}
+
+			continue
+		}
+
+		injectField, err := buildInjectInField(field)
+		if err != nil {
+			return err
+		}
+
+		injectField.container = cont
+
+		injectFields = append(injectFields, injectField)
+	}
+
+	return fillInStruct(cont, target, injectFields)
+}
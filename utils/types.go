@@ -16,6 +16,30 @@ func GetFirstReturnType(construct any) reflect.Type {
 	return ctype.Out(0)
 }
 
+// GetOutputTypes Receive a callback as an input and return the types of all its return values, except the last one if
+// it is an error. Return nil if the construct provided is not a function or is nil.
+func GetOutputTypes(construct any) []reflect.Type {
+	ctype := reflect.TypeOf(construct)
+
+	if ctype == nil || ctype.Kind() != reflect.Func {
+		return nil
+	}
+
+	nout := ctype.NumOut()
+
+	if nout > 0 && ctype.Out(nout-1) == reflect.TypeOf((*error)(nil)).Elem() {
+		nout--
+	}
+
+	outs := make([]reflect.Type, nout)
+
+	for i := 0; i < nout; i++ {
+		outs[i] = ctype.Out(i)
+	}
+
+	return outs
+}
+
 // EmbedsType checks that the provided `elem` interface embeds the provided type `e` directly. If it does, return true,
 // otherwise return false.
 func EmbedsType(elem interface{}, e reflect.Type) bool {
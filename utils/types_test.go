@@ -7,6 +7,24 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestGetOutputTypes(t *testing.T) {
+	t.Run("get types without trailing error", func(t *testing.T) {
+		fun := func() (string, int, error) { return "", 0, nil }
+
+		assert.Equal(t, []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(0)}, GetOutputTypes(fun))
+	})
+
+	t.Run("get types from function without error", func(t *testing.T) {
+		fun := func() (string, int) { return "", 0 }
+
+		assert.Equal(t, []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(0)}, GetOutputTypes(fun))
+	})
+
+	t.Run("get types from non function value", func(t *testing.T) {
+		assert.Nil(t, GetOutputTypes("fun"))
+	})
+}
+
 func TestGetFirstReturnType(t *testing.T) {
 	t.Run("get type from function with single return", func(t *testing.T) {
 		fun := func() string { return "" }